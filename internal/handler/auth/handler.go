@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"simple/internal/handler/base"
+	authLogic "simple/internal/logic/auth"
+	"simple/internal/middleware"
+	authDto "simple/internal/types/dto/auth"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : handler
+   @author  : 清风
+   @desc    : 认证接口
+   @time    : 2025/3/12 21:40
+*/
+
+type handler struct {
+	svc authLogic.IAuthService
+}
+
+// RegisterPublic 注册无需登录的认证路由
+func RegisterPublic(r *gin.RouterGroup) {
+	h := &handler{svc: authLogic.Auth()}
+
+	g := r.Group("/auth")
+	{
+		g.POST("/login", h.Login)
+		g.POST("/refresh", h.RefreshToken)
+	}
+}
+
+// Register 注册需要登录的认证路由
+func Register(r *gin.RouterGroup) {
+	h := &handler{svc: authLogic.Auth()}
+
+	g := r.Group("/auth")
+	{
+		g.POST("/logout", h.Logout)
+		g.POST("/unlock", middleware.RequirePerm("sys:auth:unlock"), h.Unlock)
+	}
+}
+
+// Login 登录
+func (h *handler) Login(ctx *gin.Context) {
+	var req authDto.LoginReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	req.ClientIP = ctx.ClientIP()
+	data, err := h.svc.Login(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// Logout 退出登录
+func (h *handler) Logout(ctx *gin.Context) {
+	var req authDto.LogoutReq
+	if !base.BindOptional(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.Logout(ctx.Request.Context(), &req))
+}
+
+// RefreshToken 刷新令牌
+func (h *handler) RefreshToken(ctx *gin.Context) {
+	var req authDto.RefreshTokenReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.RefreshToken(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// Unlock 解除登录锁定
+func (h *handler) Unlock(ctx *gin.Context) {
+	var req authDto.UnlockReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.Unlock(ctx.Request.Context(), &req))
+}
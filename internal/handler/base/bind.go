@@ -0,0 +1,54 @@
+package base
+
+import (
+	"errors"
+	"io"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : bind
+   @author  : 清风
+   @desc    : 接口层公共方法
+   @time    : 2025/3/12 21:45
+*/
+
+// Bind 绑定并校验 JSON 请求参数，失败时直接响应参数错误
+func Bind(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindJSON(req); err != nil {
+		logger.DebugContext(ctx.Request.Context(), "请求参数校验失败", zap.String("path", ctx.FullPath()), zap.Error(err))
+		resp.Res(ctx, consts.ErrInvalidParam)
+		return false
+	}
+	return true
+}
+
+// BindOptional 绑定并校验可选的 JSON 请求参数，请求体为空时按空请求处理
+func BindOptional(ctx *gin.Context, req any) bool {
+	err := ctx.ShouldBindJSON(req)
+	if errors.Is(err, io.EOF) {
+		err = binding.Validator.ValidateStruct(req)
+	}
+	if err != nil {
+		logger.DebugContext(ctx.Request.Context(), "请求参数校验失败", zap.String("path", ctx.FullPath()), zap.Error(err))
+		resp.Res(ctx, consts.ErrInvalidParam)
+		return false
+	}
+	return true
+}
+
+// BindQuery 绑定并校验查询参数，失败时直接响应参数错误
+func BindQuery(ctx *gin.Context, req any) bool {
+	if err := ctx.ShouldBindQuery(req); err != nil {
+		logger.DebugContext(ctx.Request.Context(), "请求参数校验失败", zap.String("path", ctx.FullPath()), zap.Error(err))
+		resp.Res(ctx, consts.ErrInvalidParam)
+		return false
+	}
+	return true
+}
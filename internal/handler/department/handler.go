@@ -0,0 +1,79 @@
+package department
+
+import (
+	"simple/internal/handler/base"
+	deptLogic "simple/internal/logic/department"
+	"simple/internal/middleware"
+	deptDto "simple/internal/types/dto/department"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : handler
+   @author  : 清风
+   @desc    : 部门接口
+   @time    : 2025/3/17 21:05
+*/
+
+type handler struct {
+	svc deptLogic.IDepartmentService
+}
+
+// Register 注册部门路由
+func Register(r *gin.RouterGroup) {
+	h := &handler{svc: deptLogic.Department()}
+
+	g := r.Group("/department")
+	{
+		g.POST("/create", middleware.RequirePerm("sys:dept:create"), h.CreateDepartment)
+		g.POST("/update", middleware.RequirePerm("sys:dept:update"), h.UpdateDepartment)
+		g.POST("/delete", middleware.RequirePerm("sys:dept:delete"), h.DeleteDepartment)
+		g.POST("/get", middleware.RequirePerm("sys:dept:query"), h.GetDepartment)
+		g.GET("/tree", middleware.RequirePerm("sys:dept:query"), h.TreeDepartment)
+	}
+}
+
+// CreateDepartment 创建部门
+func (h *handler) CreateDepartment(ctx *gin.Context) {
+	var req deptDto.CreateDepartmentReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.CreateDepartment(ctx.Request.Context(), &req))
+}
+
+// UpdateDepartment 更新部门
+func (h *handler) UpdateDepartment(ctx *gin.Context) {
+	var req deptDto.UpdateDepartmentReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.UpdateDepartment(ctx.Request.Context(), &req))
+}
+
+// DeleteDepartment 删除部门
+func (h *handler) DeleteDepartment(ctx *gin.Context) {
+	var req deptDto.DeleteDepartmentReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.DeleteDepartment(ctx.Request.Context(), &req))
+}
+
+// GetDepartment 获取部门
+func (h *handler) GetDepartment(ctx *gin.Context) {
+	var req deptDto.GetDepartmentReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.GetDepartment(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// TreeDepartment 部门树
+func (h *handler) TreeDepartment(ctx *gin.Context) {
+	data, err := h.svc.TreeDepartment(ctx.Request.Context())
+	resp.Res(ctx, err, data)
+}
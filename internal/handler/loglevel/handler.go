@@ -0,0 +1,58 @@
+package loglevel
+
+import (
+	"simple/internal/handler/base"
+	logLevelLogic "simple/internal/logic/loglevel"
+	"simple/internal/middleware"
+	logLevelDto "simple/internal/types/dto/loglevel"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : handler
+   @author  : 清风
+   @desc    : 运行时日志级别接口
+   @time    : 2025/3/30 20:20
+*/
+
+type handler struct {
+	svc logLevelLogic.ILogLevelService
+}
+
+// Register 注册日志级别路由
+func Register(r *gin.RouterGroup) {
+	h := &handler{svc: logLevelLogic.LogLevel()}
+
+	g := r.Group("/admin/log", middleware.RequirePerm("sys:log:level"))
+	{
+		g.GET("/level", h.GetLevel)
+		g.PUT("/level", h.SetLevel)
+		g.DELETE("/level", h.ResetLevel)
+	}
+}
+
+// GetLevel 获取当前日志级别
+func (h *handler) GetLevel(ctx *gin.Context) {
+	data, err := h.svc.GetLevel(ctx.Request.Context())
+	resp.Res(ctx, err, data)
+}
+
+// SetLevel 修改日志级别
+func (h *handler) SetLevel(ctx *gin.Context) {
+	var req logLevelDto.SetLogLevelReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.SetLevel(ctx.Request.Context(), &req))
+}
+
+// ResetLevel 恢复日志级别
+func (h *handler) ResetLevel(ctx *gin.Context) {
+	var req logLevelDto.ResetLogLevelReq
+	if !base.BindQuery(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.ResetLevel(ctx.Request.Context(), &req))
+}
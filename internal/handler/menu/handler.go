@@ -0,0 +1,92 @@
+package menu
+
+import (
+	"simple/internal/handler/base"
+	menuLogic "simple/internal/logic/menu"
+	"simple/internal/middleware"
+	menuDto "simple/internal/types/dto/menu"
+	"simple/pkg/consts"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : handler
+   @author  : 清风
+   @desc    : 菜单接口
+   @time    : 2025/3/19 21:20
+*/
+
+type handler struct {
+	svc menuLogic.IMenuService
+}
+
+// Register 注册菜单路由
+func Register(r *gin.RouterGroup) {
+	h := &handler{svc: menuLogic.Menu()}
+
+	g := r.Group("/menu")
+	{
+		g.POST("/create", middleware.RequirePerm("sys:menu:create"), h.CreateMenu)
+		g.POST("/update", middleware.RequirePerm("sys:menu:update"), h.UpdateMenu)
+		g.POST("/delete", middleware.RequirePerm("sys:menu:delete"), h.DeleteMenu)
+		g.POST("/get", middleware.RequirePerm("sys:menu:query"), h.GetMenu)
+		g.GET("/tree", middleware.RequirePerm("sys:menu:query"), h.TreeMenu)
+		g.GET("/routes", h.UserRoutes)
+	}
+}
+
+// CreateMenu 创建菜单
+func (h *handler) CreateMenu(ctx *gin.Context) {
+	var req menuDto.CreateMenuReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.CreateMenu(ctx.Request.Context(), &req))
+}
+
+// UpdateMenu 更新菜单
+func (h *handler) UpdateMenu(ctx *gin.Context) {
+	var req menuDto.UpdateMenuReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.UpdateMenu(ctx.Request.Context(), &req))
+}
+
+// DeleteMenu 删除菜单
+func (h *handler) DeleteMenu(ctx *gin.Context) {
+	var req menuDto.DeleteMenuReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.DeleteMenu(ctx.Request.Context(), &req))
+}
+
+// GetMenu 获取菜单
+func (h *handler) GetMenu(ctx *gin.Context) {
+	var req menuDto.GetMenuReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.GetMenu(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// TreeMenu 菜单树
+func (h *handler) TreeMenu(ctx *gin.Context) {
+	data, err := h.svc.TreeMenu(ctx.Request.Context())
+	resp.Res(ctx, err, data)
+}
+
+// UserRoutes 当前用户的前端路由
+func (h *handler) UserRoutes(ctx *gin.Context) {
+	claims, ok := middleware.GetClaims(ctx)
+	if !ok {
+		resp.Unauthorized(ctx, consts.ErrUnauthorized)
+		return
+	}
+	data, err := h.svc.UserRoutes(ctx.Request.Context(), claims.UserID)
+	resp.Res(ctx, err, data)
+}
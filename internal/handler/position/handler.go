@@ -0,0 +1,94 @@
+package position
+
+import (
+	"simple/internal/handler/base"
+	posLogic "simple/internal/logic/position"
+	"simple/internal/middleware"
+	posDto "simple/internal/types/dto/position"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : handler
+   @author  : 清风
+   @desc    : 岗位接口
+   @time    : 2025/3/18 21:10
+*/
+
+type handler struct {
+	svc posLogic.IPositionService
+}
+
+// Register 注册岗位路由
+func Register(r *gin.RouterGroup) {
+	h := &handler{svc: posLogic.Position()}
+
+	g := r.Group("/position")
+	{
+		g.POST("/create", middleware.RequirePerm("sys:post:create"), h.CreatePosition)
+		g.POST("/update", middleware.RequirePerm("sys:post:update"), h.UpdatePosition)
+		g.POST("/delete", middleware.RequirePerm("sys:post:delete"), h.DeletePosition)
+		g.POST("/get", middleware.RequirePerm("sys:post:query"), h.GetPosition)
+		g.POST("/list", middleware.RequirePerm("sys:post:query"), h.ListPosition)
+		g.GET("/items", middleware.RequirePerm("sys:post:query"), h.ListPositionItem)
+	}
+}
+
+// CreatePosition 创建岗位
+func (h *handler) CreatePosition(ctx *gin.Context) {
+	var req posDto.CreatePositionReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.CreatePosition(ctx.Request.Context(), &req))
+}
+
+// UpdatePosition 更新岗位
+func (h *handler) UpdatePosition(ctx *gin.Context) {
+	var req posDto.UpdatePositionReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.UpdatePosition(ctx.Request.Context(), &req))
+}
+
+// DeletePosition 删除岗位
+func (h *handler) DeletePosition(ctx *gin.Context) {
+	var req posDto.DeletePositionReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.DeletePosition(ctx.Request.Context(), &req))
+}
+
+// GetPosition 获取岗位
+func (h *handler) GetPosition(ctx *gin.Context) {
+	var req posDto.GetPositionReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.GetPosition(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// ListPosition 岗位列表
+func (h *handler) ListPosition(ctx *gin.Context) {
+	var req posDto.ListPositionReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.ListPosition(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// ListPositionItem 岗位名列表
+func (h *handler) ListPositionItem(ctx *gin.Context) {
+	var req posDto.ListPositionItemReq
+	if !base.BindQuery(ctx, &req) {
+		return
+	}
+	data, err := h.svc.ListPositionItem(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
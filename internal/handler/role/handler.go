@@ -0,0 +1,111 @@
+package role
+
+import (
+	"simple/internal/handler/base"
+	roleLogic "simple/internal/logic/role"
+	"simple/internal/middleware"
+	roleDto "simple/internal/types/dto/role"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : handler
+   @author  : 清风
+   @desc    : 角色接口
+   @time    : 2025/3/10 22:05
+*/
+
+type handler struct {
+	svc roleLogic.IRoleService
+}
+
+// Register 注册角色路由
+func Register(r *gin.RouterGroup) {
+	h := &handler{svc: roleLogic.Role()}
+
+	g := r.Group("/role")
+	{
+		g.POST("/create", middleware.RequirePerm("sys:role:create"), h.CreateRole)
+		g.POST("/update", middleware.RequirePerm("sys:role:update"), h.UpdateRole)
+		g.POST("/delete", middleware.RequirePerm("sys:role:delete"), h.DeleteRole)
+		g.POST("/get", middleware.RequirePerm("sys:role:query"), h.GetRole)
+		g.POST("/list", middleware.RequirePerm("sys:role:query"), h.ListRole)
+		g.GET("/items", middleware.RequirePerm("sys:role:query"), h.ListRoleItem)
+		g.POST("/menus", middleware.RequirePerm("sys:role:menu"), h.AssignMenus)
+		g.POST("/menus/get", middleware.RequirePerm("sys:role:query"), h.GetRoleMenus)
+	}
+}
+
+// CreateRole 创建角色
+func (h *handler) CreateRole(ctx *gin.Context) {
+	var req roleDto.CreateRoleReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.CreateRole(ctx.Request.Context(), &req))
+}
+
+// UpdateRole 更新角色
+func (h *handler) UpdateRole(ctx *gin.Context) {
+	var req roleDto.UpdateRoleReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.UpdateRole(ctx.Request.Context(), &req))
+}
+
+// DeleteRole 删除角色
+func (h *handler) DeleteRole(ctx *gin.Context) {
+	var req roleDto.DeleteRoleReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.DeleteRole(ctx.Request.Context(), &req))
+}
+
+// GetRole 获取角色
+func (h *handler) GetRole(ctx *gin.Context) {
+	var req roleDto.GetRoleReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.GetRole(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// ListRole 角色列表
+func (h *handler) ListRole(ctx *gin.Context) {
+	var req roleDto.ListRoleReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.ListRole(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// ListRoleItem 角色名列表
+func (h *handler) ListRoleItem(ctx *gin.Context) {
+	data, err := h.svc.ListRoleItem(ctx.Request.Context())
+	resp.Res(ctx, err, data)
+}
+
+// AssignMenus 分配角色菜单
+func (h *handler) AssignMenus(ctx *gin.Context) {
+	var req roleDto.AssignMenusReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.AssignMenus(ctx.Request.Context(), &req))
+}
+
+// GetRoleMenus 获取角色菜单
+func (h *handler) GetRoleMenus(ctx *gin.Context) {
+	var req roleDto.GetRoleReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.GetRoleMenus(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
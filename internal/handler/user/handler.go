@@ -0,0 +1,93 @@
+package user
+
+import (
+	"simple/internal/handler/base"
+	userLogic "simple/internal/logic/user"
+	"simple/internal/middleware"
+	userDto "simple/internal/types/dto/user"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : handler
+   @author  : 清风
+   @desc    : 用户接口
+   @time    : 2025/3/16 15:05
+*/
+
+type handler struct {
+	svc userLogic.IUserService
+}
+
+// Register 注册用户路由
+func Register(r *gin.RouterGroup) {
+	h := &handler{svc: userLogic.User()}
+
+	g := r.Group("/user")
+	{
+		g.POST("/create", middleware.RequirePerm("sys:user:create"), h.CreateUser)
+		g.POST("/update", middleware.RequirePerm("sys:user:update"), h.UpdateUser)
+		g.POST("/delete", middleware.RequirePerm("sys:user:delete"), h.DeleteUser)
+		g.POST("/get", middleware.RequirePerm("sys:user:query"), h.GetUser)
+		g.POST("/list", middleware.RequirePerm("sys:user:query"), h.ListUser)
+		g.POST("/roles", middleware.RequirePerm("sys:user:role"), h.AssignRoles)
+	}
+}
+
+// CreateUser 创建用户
+func (h *handler) CreateUser(ctx *gin.Context) {
+	var req userDto.CreateUserReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.CreateUser(ctx.Request.Context(), &req))
+}
+
+// UpdateUser 更新用户
+func (h *handler) UpdateUser(ctx *gin.Context) {
+	var req userDto.UpdateUserReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.UpdateUser(ctx.Request.Context(), &req))
+}
+
+// DeleteUser 删除用户
+func (h *handler) DeleteUser(ctx *gin.Context) {
+	var req userDto.DeleteUserReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.DeleteUser(ctx.Request.Context(), &req))
+}
+
+// GetUser 获取用户
+func (h *handler) GetUser(ctx *gin.Context) {
+	var req userDto.GetUserReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.GetUser(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// ListUser 用户列表
+func (h *handler) ListUser(ctx *gin.Context) {
+	var req userDto.ListUserReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	data, err := h.svc.ListUser(ctx.Request.Context(), &req)
+	resp.Res(ctx, err, data)
+}
+
+// AssignRoles 分配用户角色
+func (h *handler) AssignRoles(ctx *gin.Context) {
+	var req userDto.AssignRolesReq
+	if !base.Bind(ctx, &req) {
+		return
+	}
+	resp.Res(ctx, h.svc.AssignRoles(ctx.Request.Context(), &req))
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"simple/internal/global"
+	"simple/pkg/cache"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : lockout
+   @author  : 清风
+   @desc    : 登录失败计数与锁定
+   @time    : 2025/3/15 20:40
+*/
+
+// 计数与锁定的维度
+const (
+	scopeUser = "user:"
+	scopeIP   = "ip:"
+)
+
+// 未配置统计窗口与锁定时长时的默认值，为 0 时计数会被立即删除，锁定永远不会触发
+const (
+	defaultWindow   = 15 * time.Minute
+	defaultCooldown = 30 * time.Minute
+)
+
+func failKey(scope, value string) string {
+	return global.Cfg.Auth.Lockout.Prefix + "fail:" + scope + value
+}
+
+func lockKey(scope, value string) string {
+	return global.Cfg.Auth.Lockout.Prefix + "lock:" + scope + value
+}
+
+// checkLocked 检查账号或IP是否处于锁定状态
+func (s *logic) checkLocked(ctx context.Context, username, ip string) error {
+	if !global.Cfg.Auth.Lockout.Enabled {
+		return nil
+	}
+
+	for _, key := range []string{lockKey(scopeUser, username), lockKey(scopeIP, ip)} {
+		locked, err := cache.Client().Exists(ctx, key)
+		if err != nil {
+			logger.ErrorContext(ctx, "查询登录锁定状态失败", zap.String("key", key), zap.Error(err))
+			return consts.ErrServer
+		}
+		if locked {
+			return consts.ErrAccountLocked
+		}
+	}
+	return nil
+}
+
+// recordFailure 记录一次登录失败，达到阈值后锁定对应账号或IP
+func (s *logic) recordFailure(ctx context.Context, username, ip string) {
+	cfg := global.Cfg.Auth.Lockout
+	if !cfg.Enabled {
+		return
+	}
+
+	window, cooldown := cfg.Window, cfg.Cooldown
+	if window <= 0 {
+		window = defaultWindow
+	}
+	if cooldown <= 0 {
+		cooldown = defaultCooldown
+	}
+	s.incrFailure(ctx, scopeUser, username, cfg.Threshold, window, cooldown)
+	s.incrFailure(ctx, scopeIP, ip, cfg.IPThreshold, window, cooldown)
+}
+
+// 失败计数自增，计数与统计窗口原子地设置
+func (s *logic) incrFailure(ctx context.Context, scope, value string, threshold int, window, cooldown time.Duration) {
+	if value == "" || threshold <= 0 {
+		return
+	}
+
+	client := cache.Client()
+	key := failKey(scope, value)
+
+	count, err := client.IncrExpire(ctx, key, window)
+	if err != nil {
+		logger.ErrorContext(ctx, "记录登录失败次数失败", zap.String("key", key), zap.Error(err))
+		return
+	}
+	if count < int64(threshold) {
+		return
+	}
+
+	if err = client.Set(ctx, lockKey(scope, value), count, cooldown); err != nil {
+		logger.ErrorContext(ctx, "锁定登录失败", zap.String("key", key), zap.Error(err))
+		return
+	}
+	_ = client.Del(ctx, key)
+	logger.WarnContext(ctx, "登录失败次数过多，已锁定", zap.String("scope", scope), zap.String("value", value), zap.Int64("count", count))
+}
+
+// clearFailures 登录成功后清除账号的失败计数
+func (s *logic) clearFailures(ctx context.Context, username string) {
+	if !global.Cfg.Auth.Lockout.Enabled {
+		return
+	}
+	if err := cache.Client().Del(ctx, failKey(scopeUser, username)); err != nil {
+		logger.ErrorContext(ctx, "清除登录失败次数失败", zap.String("username", username), zap.Error(err))
+	}
+}
@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	authDto "simple/internal/types/dto/auth"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/cache"
+	"simple/pkg/consts"
+	"simple/pkg/jwt"
+	"simple/pkg/logger"
+	"simple/pkg/password"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : logic
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/12 21:35
+*/
+
+type logic struct{}
+
+func newLogic() *logic {
+	return &logic{}
+}
+
+// Login 账号密码登录，旧算法的密码在校验通过后自动重新哈希
+func (s *logic) Login(ctx context.Context, req *authDto.LoginReq) (*jwt.TokenPair, error) {
+	dao := query.User
+
+	// 1. 检查账号或IP是否已被锁定
+	if err := s.checkLocked(ctx, req.Username, req.ClientIP); err != nil {
+		return nil, err
+	}
+
+	// 2. 查询用户
+	user, err := dao.WithContext(ctx).Where(dao.Username.Eq(req.Username)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.recordFailure(ctx, req.Username, req.ClientIP)
+			return nil, consts.ErrUserNotFound
+		}
+		logger.ErrorContext(ctx, "查询用户失败", zap.String("username", req.Username), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	// 3. 校验密码
+	ok, needsRehash, err := password.Verify(req.Password, user.Password, user.Salt)
+	if err != nil {
+		logger.ErrorContext(ctx, "校验密码失败", zap.Int64("uid", user.ID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	if !ok {
+		s.recordFailure(ctx, req.Username, req.ClientIP)
+		return nil, consts.ErrInvalidPassword
+	}
+
+	// 4. 检查用户状态
+	if user.Status != nil && *user.Status != 1 {
+		return nil, consts.ErrUserDisabled
+	}
+	s.clearFailures(ctx, req.Username)
+
+	// 5. 旧算法或旧参数的哈希重新计算，失败不影响本次登录
+	if needsRehash {
+		s.rehash(ctx, user, req.Password)
+	}
+
+	// 6. 记录最后登录信息
+	if _, err = dao.WithContext(ctx).Where(dao.ID.Eq(user.ID)).
+		UpdateSimple(dao.LastLoginAt.Value(time.Now()), dao.LastLoginIP.Value(req.ClientIP)); err != nil {
+		logger.ErrorContext(ctx, "更新最后登录信息失败", zap.Int64("uid", user.ID), zap.Error(err))
+	}
+
+	// 7. 签发令牌
+	pair, err := jwt.Default().IssueTokenPair(ctx, &jwt.Identity{
+		UserID:   user.ID,
+		UUID:     user.UUID,
+		Username: user.Username,
+	})
+	if err != nil {
+		logger.ErrorContext(ctx, "签发令牌失败", zap.Int64("uid", user.ID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return pair, nil
+}
+
+// 使用当前配置重新计算并保存密码哈希
+func (s *logic) rehash(ctx context.Context, user *entity.User, plain string) {
+	hash, err := password.Hash(plain)
+	if err != nil {
+		logger.ErrorContext(ctx, "重新计算密码哈希失败", zap.Int64("uid", user.ID), zap.Error(err))
+		return
+	}
+
+	dao := query.User
+	if _, err = dao.WithContext(ctx).Where(dao.ID.Eq(user.ID)).
+		UpdateSimple(dao.Password.Value(hash), dao.Salt.Value("")); err != nil {
+		logger.ErrorContext(ctx, "保存密码哈希失败", zap.Int64("uid", user.ID), zap.Error(err))
+	}
+}
+
+// Logout 退出登录，将当前访问令牌及传入的刷新令牌加入黑名单，并撤销本次登录的令牌家族
+func (s *logic) Logout(ctx context.Context, req *authDto.LogoutReq) error {
+	claims, ok := jwt.FromContext(ctx)
+	if !ok {
+		return consts.ErrUnauthorized
+	}
+
+	j := jwt.Default()
+	if err := j.Revoke(ctx, claims); err != nil {
+		logger.ErrorContext(ctx, "注销访问令牌失败", zap.Int64("uid", claims.UserID), zap.String("jti", claims.ID), zap.Error(err))
+		return consts.ErrServer
+	}
+	if err := j.RevokeFamily(ctx, claims.FamilyID); err != nil {
+		logger.ErrorContext(ctx, "撤销令牌家族失败", zap.Int64("uid", claims.UserID), zap.String("fid", claims.FamilyID), zap.Error(err))
+		return consts.ErrServer
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	// 刷新令牌无效或不属于当前用户时忽略
+	refresh, err := j.ParseRefreshToken(req.RefreshToken)
+	if err != nil || refresh.UserID != claims.UserID {
+		return nil
+	}
+	if err = j.Revoke(ctx, refresh); err != nil {
+		logger.ErrorContext(ctx, "注销刷新令牌失败", zap.Int64("uid", claims.UserID), zap.String("jti", refresh.ID), zap.Error(err))
+		return consts.ErrServer
+	}
+	return nil
+}
+
+// RefreshToken 使用刷新令牌换取新的令牌对
+func (s *logic) RefreshToken(ctx context.Context, req *authDto.RefreshTokenReq) (*jwt.TokenPair, error) {
+	pair, err := jwt.Default().Refresh(ctx, req.RefreshToken)
+	if err != nil {
+		if jwt.IsTokenError(err) {
+			return nil, err
+		}
+		logger.ErrorContext(ctx, "刷新令牌失败", zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return pair, nil
+}
+
+// Unlock 解除账号或IP的登录锁定，并清除失败计数
+func (s *logic) Unlock(ctx context.Context, req *authDto.UnlockReq) error {
+	var keys []string
+	if req.Username != "" {
+		keys = append(keys, lockKey(scopeUser, req.Username), failKey(scopeUser, req.Username))
+	}
+	if req.IP != "" {
+		keys = append(keys, lockKey(scopeIP, req.IP), failKey(scopeIP, req.IP))
+	}
+
+	if err := cache.Client().Del(ctx, keys...); err != nil {
+		logger.ErrorContext(ctx, "解除登录锁定失败", zap.Any("req", req), zap.Error(err))
+		return consts.ErrServer
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	authDto "simple/internal/types/dto/auth"
+	"simple/pkg/jwt"
+)
+
+/*
+   @NAME    : service
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/12 21:32
+*/
+
+type (
+	IAuthService interface {
+		// Login 账号密码登录
+		Login(ctx context.Context, req *authDto.LoginReq) (*jwt.TokenPair, error)
+		// Logout 退出登录
+		Logout(ctx context.Context, req *authDto.LogoutReq) error
+		// RefreshToken 使用刷新令牌换取新的令牌对
+		RefreshToken(ctx context.Context, req *authDto.RefreshTokenReq) (*jwt.TokenPair, error)
+		// Unlock 解除账号或IP的登录锁定
+		Unlock(ctx context.Context, req *authDto.UnlockReq) error
+	}
+)
+
+var (
+	localAuth IAuthService
+)
+
+// Auth 获取认证服务实例
+func Auth() IAuthService {
+	if localAuth == nil {
+		localAuth = newLogic()
+	}
+	return localAuth
+}
@@ -0,0 +1,109 @@
+package builtin
+
+import (
+	"simple/model"
+	"simple/pkg/consts"
+	"slices"
+	"sync"
+)
+
+/*
+   @NAME    : builtin
+   @author  : 清风
+   @desc    : 内置数据保护，内置记录的关键字段与授权不允许变更
+   @time    : 2025/3/24 21:10
+*/
+
+// Kind 内置数据类型
+type Kind string
+
+const (
+	KindRole Kind = "role" // 角色，按角色编码识别
+	KindUser Kind = "user" // 用户，按用户名识别
+)
+
+// Field 受保护的字段或操作
+type Field string
+
+const (
+	FieldCode      Field = "code"       // 编码
+	FieldStatus    Field = "status"     // 状态
+	FieldMenus     Field = "menus"      // 角色菜单
+	FieldDataScope Field = "data_scope" // 角色数据范围
+	FieldRoles     Field = "roles"      // 用户角色
+	FieldDelete    Field = "delete"     // 删除
+)
+
+// Rule 内置数据保护规则
+type Rule struct {
+	Keys   []string // 内置记录的业务键
+	Locked []Field  // 不允许变更的字段
+}
+
+var (
+	mu    sync.RWMutex
+	rules = map[Kind]*Rule{
+		KindRole: {
+			Keys:   []string{consts.SuperAdminRoleCode},
+			Locked: []Field{FieldCode, FieldStatus, FieldMenus, FieldDataScope, FieldDelete},
+		},
+		KindUser: {
+			Keys:   []string{consts.SuperAdminUsername},
+			Locked: []Field{FieldStatus, FieldRoles, FieldDelete},
+		},
+	}
+)
+
+// Setup 注册配置中的内置角色与用户
+func Setup(cfg *model.BuiltinConfig) {
+	Register(KindRole, Rule{Keys: cfg.Roles})
+	Register(KindUser, Rule{Keys: cfg.Users})
+}
+
+// Register 注册内置数据，与已有规则合并
+func Register(kind Kind, rule Rule) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	r, ok := rules[kind]
+	if !ok {
+		r = &Rule{}
+		rules[kind] = r
+	}
+	for _, key := range rule.Keys {
+		if key != "" && !slices.Contains(r.Keys, key) {
+			r.Keys = append(r.Keys, key)
+		}
+	}
+	for _, field := range rule.Locked {
+		if !slices.Contains(r.Locked, field) {
+			r.Locked = append(r.Locked, field)
+		}
+	}
+}
+
+// IsBuiltin 判断记录是否为内置数据
+func IsBuiltin(kind Kind, key string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	r, ok := rules[kind]
+	return ok && slices.Contains(r.Keys, key)
+}
+
+// Guard 内置记录的任一字段受保护时返回 consts.ErrBuiltinImmutable
+func Guard(kind Kind, key string, fields ...Field) error {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	r, ok := rules[kind]
+	if !ok || !slices.Contains(r.Keys, key) {
+		return nil
+	}
+	for _, field := range fields {
+		if slices.Contains(r.Locked, field) {
+			return consts.ErrBuiltinImmutable
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package builtin
+
+import (
+	"errors"
+	"simple/model"
+	"simple/pkg/consts"
+	"testing"
+)
+
+// TestGuardDefaults 测试未配置时超级管理员角色与用户仍受保护
+func TestGuardDefaults(t *testing.T) {
+	Setup(&model.BuiltinConfig{})
+
+	cases := []struct {
+		kind  Kind
+		key   string
+		field Field
+		want  error
+	}{
+		{KindRole, consts.SuperAdminRoleCode, FieldMenus, consts.ErrBuiltinImmutable},
+		{KindRole, consts.SuperAdminRoleCode, FieldDataScope, consts.ErrBuiltinImmutable},
+		{KindRole, consts.SuperAdminRoleCode, FieldDelete, consts.ErrBuiltinImmutable},
+		{KindUser, consts.SuperAdminUsername, FieldStatus, consts.ErrBuiltinImmutable},
+		{KindUser, consts.SuperAdminUsername, FieldRoles, consts.ErrBuiltinImmutable},
+		{KindUser, consts.SuperAdminUsername, FieldDelete, consts.ErrBuiltinImmutable},
+		{KindUser, consts.SuperAdminUsername, FieldCode, nil},
+		{KindUser, "guest", FieldDelete, nil},
+		{KindRole, "editor", FieldDelete, nil},
+	}
+	for _, c := range cases {
+		if err := Guard(c.kind, c.key, c.field); !errors.Is(err, c.want) {
+			t.Errorf("Guard(%s, %s, %s) = %v, want %v", c.kind, c.key, c.field, err, c.want)
+		}
+	}
+}
+
+// TestRegister 测试注册的内置数据与默认规则合并
+func TestRegister(t *testing.T) {
+	Register(KindUser, Rule{Keys: []string{"ops", ""}})
+	Register(KindRole, Rule{Keys: []string{"auditor"}})
+
+	if !IsBuiltin(KindUser, "ops") || !IsBuiltin(KindUser, consts.SuperAdminUsername) {
+		t.Error("expected registered and default users to be builtin")
+	}
+	if IsBuiltin(KindUser, "") {
+		t.Error("empty key should be ignored")
+	}
+	if err := Guard(KindUser, "ops", FieldRoles); !errors.Is(err, consts.ErrBuiltinImmutable) {
+		t.Errorf("expected ErrBuiltinImmutable, got %v", err)
+	}
+	if err := Guard(KindRole, "auditor", FieldStatus); !errors.Is(err, consts.ErrBuiltinImmutable) {
+		t.Errorf("expected ErrBuiltinImmutable, got %v", err)
+	}
+
+	// 新的类型只锁定注册的字段
+	Register("dict", Rule{Keys: []string{"gender"}, Locked: []Field{FieldDelete}})
+	if err := Guard("dict", "gender", FieldStatus); err != nil {
+		t.Errorf("expected nil for unlocked field, got %v", err)
+	}
+	if err := Guard("dict", "gender", FieldStatus, FieldDelete); !errors.Is(err, consts.ErrBuiltinImmutable) {
+		t.Errorf("expected ErrBuiltinImmutable, got %v", err)
+	}
+}
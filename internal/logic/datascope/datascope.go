@@ -0,0 +1,173 @@
+package datascope
+
+import (
+	"context"
+	"errors"
+	"simple/internal/logic/department"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/consts"
+	"simple/pkg/jwt"
+	"simple/pkg/logger"
+	"slices"
+
+	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : datascope
+   @author  : 清风
+   @desc    : 基于部门的数据范围
+   @time    : 2025/3/23 20:30
+*/
+
+// 角色数据范围
+const (
+	ScopeAll        = 1 // 全部数据
+	ScopeDept       = 2 // 本部门
+	ScopeDeptAndSub = 3 // 本部门及以下
+	ScopeSelf       = 4 // 仅本人
+	ScopeCustom     = 5 // 自定义部门
+)
+
+// Scope 当前用户可见的数据范围，多个角色的范围取并集
+type Scope struct {
+	All           bool    // 不限制
+	UserID        int64   // 当前用户ID
+	Self          bool    // 可见本人的数据
+	DepartmentIds []int64 // 可见的部门
+}
+
+// Resolve 根据请求上下文中的用户解析数据范围，未登录时不可见任何数据
+func Resolve(ctx context.Context) (*Scope, error) {
+	claims, ok := jwt.FromContext(ctx)
+	if !ok {
+		return &Scope{}, nil
+	}
+	return ForUser(ctx, claims.UserID)
+}
+
+// ForUser 解析指定用户的数据范围
+func ForUser(ctx context.Context, userID int64) (*Scope, error) {
+	scope := &Scope{UserID: userID}
+
+	// 1. 用户所在部门
+	user, err := query.User.WithContext(ctx).Where(query.User.ID.Eq(userID)).Select(query.User.ID, query.User.DepartmentID).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return scope, nil
+		}
+		logger.ErrorContext(ctx, "查询用户部门失败", zap.Int64("uid", userID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	// 2. 启用的角色
+	role, userRole := query.Role, query.UserRole
+	roles, err := role.WithContext(ctx).
+		Join(userRole, userRole.RoleID.EqCol(role.ID)).
+		Where(userRole.UserID.Eq(userID), role.Status.Eq(1)).
+		Select(role.ID, role.Code, role.DataScope).
+		Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询用户角色失败", zap.Int64("uid", userID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	// 3. 合并各角色的数据范围
+	var customRoles []int64
+	for _, r := range roles {
+		if r.Code == consts.SuperAdminRoleCode {
+			scope.All = true
+			return scope, nil
+		}
+		switch dataScope(r) {
+		case ScopeAll:
+			scope.All = true
+			return scope, nil
+		case ScopeDept:
+			if user.DepartmentID != nil {
+				scope.add(*user.DepartmentID)
+			}
+		case ScopeDeptAndSub:
+			if user.DepartmentID != nil {
+				ids, err := department.Department().SubtreeIds(ctx, *user.DepartmentID)
+				if err != nil && !errors.Is(err, consts.ErrDepartmentNotFound) {
+					return nil, err
+				}
+				scope.add(ids...)
+			}
+		case ScopeSelf:
+			scope.Self = true
+		case ScopeCustom:
+			customRoles = append(customRoles, r.ID)
+		}
+	}
+
+	// 4. 自定义部门
+	if len(customRoles) > 0 {
+		var ids []int64
+		dao := query.RoleDepartment
+		if err = dao.WithContext(ctx).Distinct(dao.DepartmentID).Where(dao.RoleID.In(customRoles...)).Pluck(dao.DepartmentID, &ids); err != nil {
+			logger.ErrorContext(ctx, "查询角色自定义部门失败", zap.Int64s("roleIds", customRoles), zap.Error(err))
+			return nil, consts.ErrServer
+		}
+		scope.add(ids...)
+	}
+	return scope, nil
+}
+
+// 未设置数据范围的角色视为全部数据
+func dataScope(r *entity.Role) int64 {
+	if r.DataScope == nil {
+		return ScopeAll
+	}
+	return *r.DataScope
+}
+
+func (s *Scope) add(ids ...int64) {
+	for _, id := range ids {
+		if !slices.Contains(s.DepartmentIds, id) {
+			s.DepartmentIds = append(s.DepartmentIds, id)
+		}
+	}
+}
+
+// AllowDepartment 判断数据能否归属到指定部门，用于新增与修改时校验目标部门。
+// 未指定部门的数据只有不限制范围时可见。
+func (s *Scope) AllowDepartment(id *int64) bool {
+	if s.All {
+		return true
+	}
+	return id != nil && slices.Contains(s.DepartmentIds, *id)
+}
+
+// Filter 生成 gen 查询的数据范围条件，用于 Scopes。
+// dept 为数据所属部门字段；owner 为数据所属用户字段，没有归属用户的数据传 nil，此时"仅本人"不可见任何数据。
+func (s *Scope) Filter(dept field.Int64, owner *field.Int64) func(gen.Dao) gen.Dao {
+	return func(dao gen.Dao) gen.Dao {
+		if s.All {
+			return dao
+		}
+
+		var conds []field.Expr
+		if len(s.DepartmentIds) > 0 {
+			conds = append(conds, dept.In(s.DepartmentIds...))
+		}
+		if s.Self && owner != nil {
+			conds = append(conds, owner.Eq(s.UserID))
+		}
+
+		switch len(conds) {
+		case 0:
+			// 没有可见的部门，IN (NULL) 不匹配任何数据
+			return dao.Where(dept.In())
+		case 1:
+			return dao.Where(conds[0])
+		default:
+			return dao.Where(field.Or(conds...))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package datascope
+
+import (
+	"context"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"strings"
+	"testing"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// 只生成SQL不连接数据库的查询
+func dryRunQuery(t *testing.T) *query.Query {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "root@tcp(127.0.0.1:3306)/simple?timeout=1s",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+	return query.Use(db)
+}
+
+// TestScopeFilter 测试各数据范围生成的查询条件
+func TestScopeFilter(t *testing.T) {
+	q := dryRunQuery(t)
+	dao := q.User
+
+	cases := []struct {
+		name  string
+		scope *Scope
+		owner bool // 是否传入归属用户字段
+		want  string
+		not   string
+	}{
+		{"all", &Scope{All: true, UserID: 1}, true, "`sys_user`.`deleted_at` IS NULL", "department_id"},
+		{"self", &Scope{UserID: 1, Self: true}, true, "WHERE `sys_user`.`id` = 1 AND", "department_id"},
+		{"self without owner", &Scope{UserID: 1, Self: true}, false, "WHERE `sys_user`.`department_id` IN (NULL) AND", "`sys_user`.`id`"},
+		{"dept", &Scope{UserID: 1, DepartmentIds: []int64{2}}, true, "WHERE `sys_user`.`department_id` = 2 AND", "`sys_user`.`id`"},
+		{"dept and children", &Scope{UserID: 1, DepartmentIds: []int64{2, 3, 4}}, true, "`sys_user`.`department_id` IN (2,3,4)", ""},
+		{"custom and self", &Scope{UserID: 1, Self: true, DepartmentIds: []int64{5, 7}}, true,
+			"(`sys_user`.`department_id` IN (5,7) OR `sys_user`.`id` = 1)", ""},
+		{"empty", &Scope{UserID: 1}, true, "WHERE `sys_user`.`department_id` IN (NULL) AND", "`sys_user`.`id`"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			owner := &dao.ID
+			if !c.owner {
+				owner = nil
+			}
+			db := dao.WithContext(context.Background()).Scopes(c.scope.Filter(dao.DepartmentID, owner)).UnderlyingDB()
+			res := db.Find(&[]*entity.User{})
+			if res.Error != nil {
+				t.Fatalf("find: %v", res.Error)
+			}
+			stmt := res.Statement
+			sql := db.Dialector.Explain(stmt.SQL.String(), stmt.Vars...)
+
+			if !strings.Contains(sql, c.want) {
+				t.Errorf("sql = %s\nwant contains %s", sql, c.want)
+			}
+			if c.not != "" && strings.Contains(sql, c.not) {
+				t.Errorf("sql = %s\nshould not contain %s", sql, c.not)
+			}
+		})
+	}
+}
+
+// TestAllowDepartment 测试新增与修改时的目标部门校验
+func TestAllowDepartment(t *testing.T) {
+	dept := func(id int64) *int64 { return &id }
+	cases := []struct {
+		name  string
+		scope *Scope
+		id    *int64
+		want  bool
+	}{
+		{"all", &Scope{All: true}, dept(9), true},
+		{"all without department", &Scope{All: true}, nil, true},
+		{"in scope", &Scope{DepartmentIds: []int64{2, 3}}, dept(3), true},
+		{"out of scope", &Scope{DepartmentIds: []int64{2, 3}}, dept(9), false},
+		{"without department", &Scope{DepartmentIds: []int64{2}}, nil, false},
+		{"self only", &Scope{Self: true, UserID: 1}, dept(2), false},
+	}
+	for _, c := range cases {
+		if got := c.scope.AllowDepartment(c.id); got != c.want {
+			t.Errorf("%s: AllowDepartment = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package department
+
+import (
+	"context"
+	"errors"
+	"simple/internal/global"
+	deptDto "simple/internal/types/dto/department"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"slices"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+/*
+   @NAME    : logic
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/17 20:20
+*/
+
+type logic struct{}
+
+func newLogic() *logic {
+	return &logic{}
+}
+
+// CreateDepartment 创建部门
+func (s *logic) CreateDepartment(ctx context.Context, req *deptDto.CreateDepartmentReq) error {
+	parentID := normalizeParent(req.ParentID)
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		// 1. 检查上级部门
+		if parentID != nil {
+			if _, err := findDepartment(ctx, tx, *parentID); err != nil {
+				if errors.Is(err, consts.ErrDepartmentNotFound) {
+					return consts.ErrDepartmentParent
+				}
+				return err
+			}
+		}
+
+		// 2. 检查名称与编码
+		if err := checkUnique(ctx, tx, 0, parentID, req.Name, req.Code); err != nil {
+			return err
+		}
+
+		// 3. 创建部门
+		d := &entity.Department{
+			ParentID: parentID,
+			Name:     req.Name,
+			Code:     req.Code,
+			Leader:   req.Leader,
+			Phone:    req.Phone,
+			Email:    req.Email,
+			Sort:     req.Sort,
+			Status:   req.Status,
+			Remark:   req.Remark,
+		}
+		if err := tx.Department.WithContext(ctx).Create(d); err != nil {
+			logger.ErrorContext(ctx, "创建部门失败", zap.Any("department", d), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// UpdateDepartment 更新部门
+func (s *logic) UpdateDepartment(ctx context.Context, req *deptDto.UpdateDepartmentReq) error {
+	parentID := normalizeParent(req.ParentID)
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.Department
+		do := dao.WithContext(ctx)
+
+		// 1. 检查部门是否存在
+		if _, err := findDepartment(ctx, tx, req.ID); err != nil {
+			return err
+		}
+
+		// 2. 检查上级部门，不能挂到自身或自身的下级部门下。
+		// 加锁读取部门树，并发调整上级部门时串行执行，避免各自检查通过后形成环
+		if parentID != nil {
+			list, err := lockDepartments(ctx, tx)
+			if err != nil {
+				return err
+			}
+			if !slices.ContainsFunc(list, func(d *entity.Department) bool { return d.ID == *parentID }) {
+				return consts.ErrDepartmentParent
+			}
+			if slices.Contains(subtree(list, req.ID), *parentID) {
+				return consts.ErrDepartmentParent
+			}
+		}
+
+		// 3. 检查名称与编码
+		if err := checkUnique(ctx, tx, req.ID, parentID, req.Name, req.Code); err != nil {
+			return err
+		}
+
+		// 4. 更新部门，上级部门为空时需要显式置空
+		d := &entity.Department{
+			Name:   req.Name,
+			Code:   req.Code,
+			Leader: req.Leader,
+			Phone:  req.Phone,
+			Email:  req.Email,
+			Sort:   req.Sort,
+			Status: req.Status,
+			Remark: req.Remark,
+		}
+		if _, err := do.Where(dao.ID.Eq(req.ID)).Updates(d); err != nil {
+			logger.ErrorContext(ctx, "更新部门失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		parent := dao.ParentID.Null()
+		if parentID != nil {
+			parent = dao.ParentID.Value(*parentID)
+		}
+		if _, err := do.Where(dao.ID.Eq(req.ID)).UpdateSimple(parent); err != nil {
+			logger.ErrorContext(ctx, "更新上级部门失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// DeleteDepartment 批量删除部门
+func (s *logic) DeleteDepartment(ctx context.Context, req *deptDto.DeleteDepartmentReq) error {
+	if len(req.Ids) == 0 {
+		return nil
+	}
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.Department
+		do := dao.WithContext(ctx)
+
+		// 1. 检查部门是否都存在
+		count, err := do.Where(dao.ID.In(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询部门失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if int(count) != len(req.Ids) {
+			return consts.ErrDepartmentNotFound
+		}
+
+		// 2. 存在未一并删除的子部门时拒绝删除
+		count, err = do.Where(dao.ParentID.In(req.Ids...), dao.ID.NotIn(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询子部门失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count > 0 {
+			return consts.ErrDepartmentHasChildren
+		}
+
+		// 3. 部门下存在用户时拒绝删除
+		count, err = tx.User.WithContext(ctx).Where(tx.User.DepartmentID.In(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询部门用户失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count > 0 {
+			return consts.ErrDepartmentHasUsers
+		}
+
+		// 4. 部门下存在岗位时拒绝删除
+		count, err = tx.Position.WithContext(ctx).Where(tx.Position.DepartmentID.In(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询部门岗位失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count > 0 {
+			return consts.ErrDepartmentHasPositions
+		}
+
+		// 5. 软删除部门
+		if _, err = do.Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
+			logger.ErrorContext(ctx, "删除部门失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// GetDepartment 获取部门
+func (s *logic) GetDepartment(ctx context.Context, req *deptDto.GetDepartmentReq) (*entity.Department, error) {
+	return findDepartment(ctx, query.Q, req.ID)
+}
+
+// TreeDepartment 完整部门树
+func (s *logic) TreeDepartment(ctx context.Context) ([]*entity.Department, error) {
+	list, err := loadDepartments(ctx, query.Q)
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(list), nil
+}
+
+// SubtreeIds 获取部门及其所有下级部门的ID
+func (s *logic) SubtreeIds(ctx context.Context, id int64) ([]int64, error) {
+	list, err := loadDepartments(ctx, query.Q)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(list, func(d *entity.Department) bool { return d.ID == id }) {
+		return nil, consts.ErrDepartmentNotFound
+	}
+	return subtree(list, id), nil
+}
+
+// 上级部门ID为0时视为顶级部门
+func normalizeParent(parentID *int64) *int64 {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+	return parentID
+}
+
+// 查询单个部门
+func findDepartment(ctx context.Context, q *query.Query, id int64) (*entity.Department, error) {
+	d, err := q.Department.WithContext(ctx).Where(q.Department.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, consts.ErrDepartmentNotFound
+		}
+		logger.ErrorContext(ctx, "查询部门失败", zap.Int64("id", id), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return d, nil
+}
+
+// 检查同级部门名称与全局部门编码是否重复，excludeID 为当前更新的部门
+func checkUnique(ctx context.Context, tx *query.Query, excludeID int64, parentID *int64, name, code string) error {
+	dao := tx.Department
+
+	// 同级部门名称不能重复
+	do := dao.WithContext(ctx).Where(dao.Name.Eq(name), dao.ID.Neq(excludeID))
+	if parentID == nil {
+		do = do.Where(dao.ParentID.IsNull())
+	} else {
+		do = do.Where(dao.ParentID.Eq(*parentID))
+	}
+	count, err := do.Count()
+	if err != nil {
+		logger.ErrorContext(ctx, "检查部门名称是否重复失败", zap.String("name", name), zap.Error(err))
+		return consts.ErrServer
+	}
+	if count > 0 {
+		return consts.ErrDepartmentNameExists
+	}
+
+	// 编码为唯一索引，已删除的部门同样占用
+	count, err = dao.WithContext(ctx).Unscoped().Where(dao.Code.Eq(code), dao.ID.Neq(excludeID)).Count()
+	if err != nil {
+		logger.ErrorContext(ctx, "检查部门编码是否重复失败", zap.String("code", code), zap.Error(err))
+		return consts.ErrServer
+	}
+	if count > 0 {
+		return consts.ErrDepartmentCodeExists
+	}
+	return nil
+}
+
+// 查询全部部门，部门数量有限，树相关计算均在内存中完成
+func loadDepartments(ctx context.Context, q *query.Query) ([]*entity.Department, error) {
+	dao := q.Department
+	list, err := dao.WithContext(ctx).Order(dao.Sort, dao.ID).Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询部门列表失败", zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return list, nil
+}
+
+// 在事务中加锁读取所有部门，用于调整上级部门前的环检查
+func lockDepartments(ctx context.Context, tx *query.Query) ([]*entity.Department, error) {
+	dao := tx.Department
+	list, err := dao.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Order(dao.Sort, dao.ID).Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "锁定部门列表失败", zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return list, nil
+}
+
+// 按上级部门ID分组，顶级部门的键为0
+func groupByParent(list []*entity.Department) map[int64][]*entity.Department {
+	children := make(map[int64][]*entity.Department, len(list))
+	for _, d := range list {
+		var parentID int64
+		if d.ParentID != nil {
+			parentID = *d.ParentID
+		}
+		children[parentID] = append(children[parentID], d)
+	}
+	return children
+}
+
+// 组装部门树，上级部门已删除的部门作为顶级部门展示
+func buildTree(list []*entity.Department) []*entity.Department {
+	children := groupByParent(list)
+	exists := make(map[int64]bool, len(list))
+	for _, d := range list {
+		exists[d.ID] = true
+	}
+
+	roots := make([]*entity.Department, 0)
+	for _, d := range list {
+		d.Children = children[d.ID]
+		if d.ParentID == nil || !exists[*d.ParentID] {
+			roots = append(roots, d)
+		}
+	}
+	return roots
+}
+
+// 获取部门及其所有下级部门的ID
+func subtree(list []*entity.Department, id int64) []int64 {
+	children := groupByParent(list)
+	ids := []int64{id}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			// 防御历史数据中的环
+			if !slices.Contains(ids, child.ID) {
+				ids = append(ids, child.ID)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,43 @@
+package department
+
+import (
+	"context"
+	deptDto "simple/internal/types/dto/department"
+	"simple/internal/types/entity"
+)
+
+/*
+   @NAME    : service
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/17 20:15
+*/
+
+type (
+	IDepartmentService interface {
+		// CreateDepartment 创建部门
+		CreateDepartment(ctx context.Context, req *deptDto.CreateDepartmentReq) error
+		// UpdateDepartment 更新部门
+		UpdateDepartment(ctx context.Context, req *deptDto.UpdateDepartmentReq) error
+		// DeleteDepartment 删除部门，存在子部门或用户时拒绝删除
+		DeleteDepartment(ctx context.Context, req *deptDto.DeleteDepartmentReq) error
+		// GetDepartment 获取部门
+		GetDepartment(ctx context.Context, req *deptDto.GetDepartmentReq) (*entity.Department, error)
+		// TreeDepartment 完整部门树
+		TreeDepartment(ctx context.Context) ([]*entity.Department, error)
+		// SubtreeIds 获取部门及其所有下级部门的ID，用于按部门子树过滤
+		SubtreeIds(ctx context.Context, id int64) ([]int64, error)
+	}
+)
+
+var (
+	localDepartment IDepartmentService
+)
+
+// Department 获取部门服务实例
+func Department() IDepartmentService {
+	if localDepartment == nil {
+		localDepartment = newLogic()
+	}
+	return localDepartment
+}
@@ -0,0 +1,61 @@
+package loglevel
+
+import (
+	"context"
+	logLevelDto "simple/internal/types/dto/loglevel"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : logic
+   @author  : 清风
+   @desc    : 运行时日志级别
+   @time    : 2025/3/30 20:15
+*/
+
+type logic struct{}
+
+func newLogic() *logic {
+	return &logic{}
+}
+
+// GetLevel 获取当前日志级别
+func (s *logic) GetLevel(ctx context.Context) (*logger.LevelState, error) {
+	state := logger.Levels()
+	return &state, nil
+}
+
+// SetLevel 修改全局或指定名称的日志级别
+func (s *logic) SetLevel(ctx context.Context, req *logLevelDto.SetLogLevelReq) error {
+	ttl := time.Duration(req.TTL) * time.Second
+
+	var err error
+	if req.Name == "" {
+		err = logger.SetLevel(req.Level, ttl)
+	} else {
+		err = logger.SetNamedLevel(req.Name, req.Level, ttl)
+	}
+	if err != nil {
+		return consts.ErrInvalidParam
+	}
+
+	// 记录操作，修改为更高级别时也能看到
+	logger.WarnContext(ctx, "修改日志级别", zap.String("name", req.Name), zap.String("level", req.Level), zap.Duration("ttl", ttl))
+	return nil
+}
+
+// ResetLevel 恢复全局级别或移除指定名称的级别
+func (s *logic) ResetLevel(ctx context.Context, req *logLevelDto.ResetLogLevelReq) error {
+	if req.Name == "" {
+		logger.ResetLevel()
+	} else {
+		logger.ResetNamedLevel(req.Name)
+	}
+
+	logger.WarnContext(ctx, "恢复日志级别", zap.String("name", req.Name))
+	return nil
+}
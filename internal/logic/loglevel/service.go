@@ -0,0 +1,37 @@
+package loglevel
+
+import (
+	"context"
+	logLevelDto "simple/internal/types/dto/loglevel"
+	"simple/pkg/logger"
+)
+
+/*
+   @NAME    : service
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/30 20:12
+*/
+
+type (
+	ILogLevelService interface {
+		// GetLevel 获取当前日志级别
+		GetLevel(ctx context.Context) (*logger.LevelState, error)
+		// SetLevel 修改全局或指定名称的日志级别
+		SetLevel(ctx context.Context, req *logLevelDto.SetLogLevelReq) error
+		// ResetLevel 恢复全局级别或移除指定名称的级别
+		ResetLevel(ctx context.Context, req *logLevelDto.ResetLogLevelReq) error
+	}
+)
+
+var (
+	localLogLevel ILogLevelService
+)
+
+// LogLevel 获取日志级别服务实例
+func LogLevel() ILogLevelService {
+	if localLogLevel == nil {
+		localLogLevel = newLogic()
+	}
+	return localLogLevel
+}
@@ -0,0 +1,386 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	"simple/internal/global"
+	"simple/internal/logic/permission"
+	menuDto "simple/internal/types/dto/menu"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"slices"
+
+	"go.uber.org/zap"
+	"gorm.io/gen"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : logic
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/19 20:45
+*/
+
+type logic struct{}
+
+func newLogic() *logic {
+	return &logic{}
+}
+
+// CreateMenu 创建菜单
+func (s *logic) CreateMenu(ctx context.Context, req *menuDto.CreateMenuReq) error {
+	if err := validateType(&req.MenuReq); err != nil {
+		return err
+	}
+	parentID := normalizeParent(req.ParentID)
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		// 1. 检查上级菜单并计算层级
+		level, err := parentLevel(ctx, tx, parentID)
+		if err != nil {
+			return err
+		}
+
+		// 2. 检查菜单名称
+		if err = checkName(ctx, tx, 0, req.Name); err != nil {
+			return err
+		}
+
+		// 3. 创建菜单
+		m := newMenu(&req.MenuReq)
+		m.ParentID = parentID
+		m.Level = &level
+		if err = tx.Menu.WithContext(ctx).Create(m); err != nil {
+			logger.ErrorContext(ctx, "创建菜单失败", zap.Any("menu", m), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// UpdateMenu 更新菜单
+func (s *logic) UpdateMenu(ctx context.Context, req *menuDto.UpdateMenuReq) error {
+	if err := validateType(&req.MenuReq); err != nil {
+		return err
+	}
+	parentID := normalizeParent(req.ParentID)
+
+	err := global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.Menu
+		do := dao.WithContext(ctx)
+
+		// 1. 检查菜单是否存在
+		list, err := loadMenus(ctx, tx)
+		if err != nil {
+			return err
+		}
+		idx := slices.IndexFunc(list, func(m *entity.Menu) bool { return m.ID == req.ID })
+		if idx < 0 {
+			return consts.ErrMenuNotFound
+		}
+		old := list[idx]
+		children := groupByParent(list)
+
+		// 2. 按钮不能包含子菜单
+		if req.Type == TypeButton && len(children[req.ID]) > 0 {
+			return consts.ErrMenuHasChildren
+		}
+
+		// 3. 检查上级菜单，不能挂到自身或自身的下级菜单下
+		if parentID != nil && slices.Contains(subtree(children, req.ID), *parentID) {
+			return consts.ErrMenuParentInvalid
+		}
+		level, err := parentLevel(ctx, tx, parentID)
+		if err != nil {
+			return err
+		}
+
+		// 4. 检查菜单名称
+		if err = checkName(ctx, tx, req.ID, req.Name); err != nil {
+			return err
+		}
+
+		// 5. 更新菜单，零值字段与上级菜单需要显式更新
+		if _, err = do.Where(dao.ID.Eq(req.ID)).Updates(newMenu(&req.MenuReq)); err != nil {
+			logger.ErrorContext(ctx, "更新菜单失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		parent := dao.ParentID.Null()
+		if parentID != nil {
+			parent = dao.ParentID.Value(*parentID)
+		}
+		if _, err = do.Where(dao.ID.Eq(req.ID)).UpdateSimple(
+			parent,
+			dao.Type.Value(req.Type),
+			dao.Sort.Value(req.Sort),
+			dao.Level.Value(level),
+		); err != nil {
+			logger.ErrorContext(ctx, "更新菜单失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		// 6. 层级变化时同步调整所有下级菜单
+		if old.Level != nil && *old.Level == level {
+			return nil
+		}
+		return updateChildLevels(ctx, tx, children, req.ID, level)
+	})
+	if err == nil {
+		permission.Permission().InvalidateMenus(ctx, req.ID)
+	}
+	return err
+}
+
+// DeleteMenu 批量删除菜单
+func (s *logic) DeleteMenu(ctx context.Context, req *menuDto.DeleteMenuReq) error {
+	if len(req.Ids) == 0 {
+		return nil
+	}
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.Menu
+		do := dao.WithContext(ctx)
+
+		// 1. 检查菜单是否都存在
+		count, err := do.Where(dao.ID.In(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询菜单失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if int(count) != len(req.Ids) {
+			return consts.ErrMenuNotFound
+		}
+
+		// 2. 存在未一并删除的子菜单时拒绝删除
+		count, err = do.Where(dao.ParentID.In(req.Ids...), dao.ID.NotIn(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询子菜单失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count > 0 {
+			return consts.ErrMenuHasChildren
+		}
+
+		// 3. 菜单仍被角色使用时拒绝删除
+		count, err = tx.RoleMenu.WithContext(ctx).Where(tx.RoleMenu.MenuID.In(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询菜单关联角色失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count > 0 {
+			return consts.ErrMenuHasRoles
+		}
+
+		// 4. 软删除菜单
+		if _, err = do.Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
+			logger.ErrorContext(ctx, "删除菜单失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// GetMenu 获取菜单
+func (s *logic) GetMenu(ctx context.Context, req *menuDto.GetMenuReq) (*entity.Menu, error) {
+	return findMenu(ctx, query.Q, req.ID)
+}
+
+// TreeMenu 完整菜单树
+func (s *logic) TreeMenu(ctx context.Context) ([]*entity.Menu, error) {
+	list, err := loadMenus(ctx, query.Q)
+	if err != nil {
+		return nil, err
+	}
+	return BuildTree(list), nil
+}
+
+// 按菜单类型校验必填项
+func validateType(req *menuDto.MenuReq) error {
+	switch req.Type {
+	case TypeButton:
+		if req.Permission == nil || *req.Permission == "" {
+			return consts.ErrMenuNoPermission
+		}
+	case TypeMenu:
+		if (req.Component == nil || *req.Component == "") && (req.FrameSrc == nil || *req.FrameSrc == "") {
+			return consts.ErrMenuNoComponent
+		}
+	}
+	return nil
+}
+
+// 上级菜单ID为0时视为顶级菜单
+func normalizeParent(parentID *int64) *int64 {
+	if parentID == nil || *parentID == 0 {
+		return nil
+	}
+	return parentID
+}
+
+// 检查上级菜单并返回当前菜单的层级，按钮下不能再挂载菜单
+func parentLevel(ctx context.Context, tx *query.Query, parentID *int64) (int64, error) {
+	if parentID == nil {
+		return 1, nil
+	}
+
+	parent, err := findMenu(ctx, tx, *parentID)
+	if err != nil {
+		if errors.Is(err, consts.ErrMenuNotFound) {
+			return 0, consts.ErrMenuParentNotFound
+		}
+		return 0, err
+	}
+	if parent.Type == TypeButton {
+		return 0, consts.ErrMenuParentInvalid
+	}
+
+	var level int64 = 1
+	if parent.Level != nil {
+		level = *parent.Level
+	}
+	return level + 1, nil
+}
+
+// 检查菜单名称是否重复，excludeID 为当前更新的菜单
+func checkName(ctx context.Context, tx *query.Query, excludeID int64, name string) error {
+	dao := tx.Menu
+	count, err := dao.WithContext(ctx).Where(dao.Name.Eq(name), dao.ID.Neq(excludeID)).Count()
+	if err != nil {
+		logger.ErrorContext(ctx, "检查菜单名称是否重复失败", zap.String("name", name), zap.Error(err))
+		return consts.ErrServer
+	}
+	if count > 0 {
+		return consts.ErrMenuNameExists
+	}
+	return nil
+}
+
+// 根据请求构造菜单实体
+func newMenu(req *menuDto.MenuReq) *entity.Menu {
+	return &entity.Menu{
+		Title:              req.Title,
+		Name:               req.Name,
+		Path:               req.Path,
+		Component:          req.Component,
+		Redirect:           req.Redirect,
+		Icon:               req.Icon,
+		Type:               req.Type,
+		Permission:         req.Permission,
+		Sort:               req.Sort,
+		IsHidden:           req.IsHidden,
+		IsCache:            req.IsCache,
+		IsAffix:            req.IsAffix,
+		Trans:              req.Trans,
+		HideBreadcrumb:     req.HideBreadcrumb,
+		HideTab:            req.HideTab,
+		FrameSrc:           req.FrameSrc,
+		CarryParam:         req.CarryParam,
+		HideChildrenInMenu: req.HideChildrenInMenu,
+		DynamicLevel:       req.DynamicLevel,
+		RealPath:           req.RealPath,
+		Status:             req.Status,
+	}
+}
+
+// 按新的层级逐层更新下级菜单
+func updateChildLevels(ctx context.Context, tx *query.Query, children map[int64][]*entity.Menu, id, level int64) error {
+	dao := tx.Menu
+	visited := map[int64]bool{id: true}
+	parents := []int64{id}
+	for len(parents) > 0 {
+		level++
+
+		var ids []int64
+		for _, parentID := range parents {
+			for _, child := range children[parentID] {
+				// 防御历史数据中的环
+				if !visited[child.ID] {
+					visited[child.ID] = true
+					ids = append(ids, child.ID)
+				}
+			}
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+
+		if _, err := dao.WithContext(ctx).Where(dao.ID.In(ids...)).UpdateSimple(dao.Level.Value(level)); err != nil {
+			logger.ErrorContext(ctx, "更新子菜单层级失败", zap.Int64("id", id), zap.Error(err))
+			return consts.ErrServer
+		}
+		parents = ids
+	}
+	return nil
+}
+
+// 查询单个菜单
+func findMenu(ctx context.Context, q *query.Query, id int64) (*entity.Menu, error) {
+	m, err := q.Menu.WithContext(ctx).Where(q.Menu.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, consts.ErrMenuNotFound
+		}
+		logger.ErrorContext(ctx, "查询菜单失败", zap.Int64("id", id), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return m, nil
+}
+
+// 查询全部菜单，conds 为附加的过滤条件
+func loadMenus(ctx context.Context, q *query.Query, conds ...gen.Condition) ([]*entity.Menu, error) {
+	dao := q.Menu
+	list, err := dao.WithContext(ctx).Where(conds...).Order(dao.Sort, dao.ID).Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询菜单列表失败", zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return list, nil
+}
+
+// 按上级菜单ID分组，顶级菜单的键为0
+func groupByParent(list []*entity.Menu) map[int64][]*entity.Menu {
+	children := make(map[int64][]*entity.Menu, len(list))
+	for _, m := range list {
+		var parentID int64
+		if m.ParentID != nil {
+			parentID = *m.ParentID
+		}
+		children[parentID] = append(children[parentID], m)
+	}
+	return children
+}
+
+// BuildTree 组装菜单树，上级菜单不在列表中的菜单作为顶级菜单
+func BuildTree(list []*entity.Menu) []*entity.Menu {
+	children := groupByParent(list)
+	exists := make(map[int64]bool, len(list))
+	for _, m := range list {
+		exists[m.ID] = true
+	}
+
+	roots := make([]*entity.Menu, 0)
+	for _, m := range list {
+		m.Children = children[m.ID]
+		if m.ParentID == nil || !exists[*m.ParentID] {
+			roots = append(roots, m)
+		}
+	}
+	return roots
+}
+
+// 获取菜单及其所有下级菜单的ID
+func subtree(children map[int64][]*entity.Menu, id int64) []int64 {
+	ids := []int64{id}
+	for i := 0; i < len(ids); i++ {
+		for _, child := range children[ids[i]] {
+			if !slices.Contains(ids, child.ID) {
+				ids = append(ids, child.ID)
+			}
+		}
+	}
+	return ids
+}
@@ -0,0 +1,126 @@
+package menu
+
+import (
+	"context"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/logger"
+	"slices"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// 只生成SQL不连接数据库的查询，返回执行过的更新语句
+func dryRunQuery(t *testing.T) (*query.Query, *[]string) {
+	t.Helper()
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		DSN:                       "root@tcp(127.0.0.1:3306)/simple?timeout=1s",
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+		Logger:                 gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatalf("open dry run db: %v", err)
+	}
+
+	var updates []string
+	err = db.Callback().Update().After("gorm:update").Register("test:capture", func(db *gorm.DB) {
+		updates = append(updates, db.Dialector.Explain(db.Statement.SQL.String(), db.Statement.Vars...))
+	})
+	if err != nil {
+		t.Fatalf("register callback: %v", err)
+	}
+	return query.Use(db), &updates
+}
+
+func newTestMenu(id int64, parentID int64) *entity.Menu {
+	m := &entity.Menu{ID: id, Type: TypeMenu}
+	if parentID != 0 {
+		m.ParentID = &parentID
+	}
+	return m
+}
+
+// TestSubtree 测试获取下级菜单，包含环时不会死循环
+func TestSubtree(t *testing.T) {
+	cases := []struct {
+		name string
+		list []*entity.Menu
+		id   int64
+		want []int64
+	}{
+		{"leaf", []*entity.Menu{newTestMenu(1, 0), newTestMenu(2, 1)}, 2, []int64{2}},
+		{"tree", []*entity.Menu{newTestMenu(1, 0), newTestMenu(2, 1), newTestMenu(3, 1), newTestMenu(4, 2)}, 1, []int64{1, 2, 3, 4}},
+		{"cycle", []*entity.Menu{newTestMenu(1, 3), newTestMenu(2, 1), newTestMenu(3, 2)}, 1, []int64{1, 2, 3}},
+	}
+	for _, c := range cases {
+		got := subtree(groupByParent(c.list), c.id)
+		slices.Sort(got)
+		if !slices.Equal(got, c.want) {
+			t.Errorf("%s: subtree = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+// TestUpdateChildLevels 测试逐层更新下级菜单层级，遇到环时停止
+func TestUpdateChildLevels(t *testing.T) {
+	logger.Log = zap.NewNop()
+	q, updates := dryRunQuery(t)
+
+	// 1 -> 2 -> 4 -> 1 构成环，3 为 1 的另一个子菜单
+	list := []*entity.Menu{newTestMenu(1, 4), newTestMenu(2, 1), newTestMenu(3, 1), newTestMenu(4, 2)}
+	if err := updateChildLevels(context.Background(), q, groupByParent(list), 1, 2); err != nil {
+		t.Fatalf("updateChildLevels failed: %v", err)
+	}
+
+	want := []struct{ level, ids string }{
+		{"`level`=3", "IN (2,3)"},
+		{"`level`=4", "= 4"},
+	}
+	if len(*updates) != len(want) {
+		t.Fatalf("updates = %v, want %d statements", *updates, len(want))
+	}
+	for i, w := range want {
+		sql := (*updates)[i]
+		if !strings.Contains(sql, w.level) || !strings.Contains(sql, "`sys_menu`.`id` "+w.ids) {
+			t.Errorf("update %d = %s, want %s and id %s", i, sql, w.level, w.ids)
+		}
+	}
+}
+
+// TestVisible 测试上级菜单缺失（已禁用）或存在环时菜单不可见
+func TestVisible(t *testing.T) {
+	byID := func(list ...*entity.Menu) map[int64]*entity.Menu {
+		m := make(map[int64]*entity.Menu, len(list))
+		for _, menu := range list {
+			m[menu.ID] = menu
+		}
+		return m
+	}
+
+	cases := []struct {
+		name string
+		menu *entity.Menu
+		byID map[int64]*entity.Menu
+		want bool
+	}{
+		{"top level", newTestMenu(1, 0), byID(newTestMenu(1, 0)), true},
+		{"enabled ancestors", newTestMenu(3, 2), byID(newTestMenu(1, 0), newTestMenu(2, 1), newTestMenu(3, 2)), true},
+		{"disabled parent", newTestMenu(3, 2), byID(newTestMenu(1, 0), newTestMenu(3, 2)), false},
+		{"disabled ancestor", newTestMenu(3, 2), byID(newTestMenu(2, 1), newTestMenu(3, 2)), false},
+		{"cycle", newTestMenu(1, 2), byID(newTestMenu(1, 2), newTestMenu(2, 1)), false},
+	}
+	for _, c := range cases {
+		if got := visible(c.menu, c.byID); got != c.want {
+			t.Errorf("%s: visible = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package menu
+
+import (
+	"context"
+	"errors"
+	menuDto "simple/internal/types/dto/menu"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"slices"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : route
+   @author  : 清风
+   @desc    : 根据用户角色生成前端动态路由
+   @time    : 2025/3/22 20:30
+*/
+
+// 前端内置组件
+const (
+	componentLayout = "LAYOUT"
+	componentIframe = "IFRAME"
+)
+
+// 默认首页
+const defaultHomePath = "/dashboard"
+
+// UserRoutes 当前用户的前端路由、按钮权限与首页
+func (s *logic) UserRoutes(ctx context.Context, userID int64) (*menuDto.UserRoutesResp, error) {
+	// 1. 用户信息与启用的角色
+	user, err := query.User.WithContext(ctx).Where(query.User.ID.Eq(userID)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, consts.ErrUserNotFound
+		}
+		logger.ErrorContext(ctx, "查询用户失败", zap.Int64("uid", userID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	role, userRole := query.Role, query.UserRole
+	roles, err := role.WithContext(ctx).
+		Join(userRole, userRole.RoleID.EqCol(role.ID)).
+		Where(userRole.UserID.Eq(userID), role.Status.Eq(1)).
+		Order(role.Sort).
+		Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询用户角色失败", zap.Int64("uid", userID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	// 2. 启用的菜单与角色授权的菜单，超级管理员拥有全部菜单
+	menus, err := loadMenus(ctx, query.Q, query.Menu.Status.Eq(1))
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*entity.Menu, len(menus))
+	for _, m := range menus {
+		byID[m.ID] = m
+	}
+	var granted map[int64]bool
+	if slices.ContainsFunc(roles, func(r *entity.Role) bool { return r.Code == consts.SuperAdminRoleCode }) {
+		granted = make(map[int64]bool, len(menus))
+		for _, m := range menus {
+			granted[m.ID] = true
+		}
+	} else if granted, err = grantedMenus(ctx, byID, roles); err != nil {
+		return nil, err
+	}
+
+	// 3. 拆分路由与按钮权限，路由只保留所有上级菜单均启用的菜单，
+	// 按钮权限与权限校验一致，只要求按钮已授权且启用
+	routes := make([]*entity.Menu, 0, len(menus))
+	codes := make([]string, 0)
+	for _, m := range menus {
+		if !granted[m.ID] {
+			continue
+		}
+		if m.Type == TypeButton {
+			if m.Permission != nil && *m.Permission != "" && !slices.Contains(codes, *m.Permission) {
+				codes = append(codes, *m.Permission)
+			}
+			continue
+		}
+		if visible(m, byID) {
+			routes = append(routes, m)
+		}
+	}
+	slices.Sort(codes)
+
+	return &menuDto.UserRoutesResp{
+		Routes:      toRoutes(BuildTree(routes)),
+		Permissions: codes,
+		HomePath:    homePath(user, roles),
+	}, nil
+}
+
+// 角色已授权的菜单，并补齐授权菜单的上级菜单
+func grantedMenus(ctx context.Context, byID map[int64]*entity.Menu, roles []*entity.Role) (map[int64]bool, error) {
+	if len(roles) == 0 {
+		return nil, nil
+	}
+	roleIds := make([]int64, 0, len(roles))
+	for _, r := range roles {
+		roleIds = append(roleIds, r.ID)
+	}
+
+	var menuIds []int64
+	dao := query.RoleMenu
+	if err := dao.WithContext(ctx).Distinct(dao.MenuID).Where(dao.RoleID.In(roleIds...)).Pluck(dao.MenuID, &menuIds); err != nil {
+		logger.ErrorContext(ctx, "查询角色菜单失败", zap.Int64s("roleIds", roleIds), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	granted := make(map[int64]bool, len(menuIds))
+	for _, id := range menuIds {
+		// 逐级向上补齐，上级菜单被禁用时由 visible 过滤整条分支
+		for m := byID[id]; m != nil && !granted[m.ID]; {
+			granted[m.ID] = true
+			if m.ParentID == nil {
+				break
+			}
+			m = byID[*m.ParentID]
+		}
+	}
+	return granted, nil
+}
+
+// 所有上级菜单均启用时菜单才可见
+func visible(m *entity.Menu, byID map[int64]*entity.Menu) bool {
+	for depth := 0; m.ParentID != nil; depth++ {
+		parent, ok := byID[*m.ParentID]
+		if !ok || depth > len(byID) {
+			return false
+		}
+		m = parent
+	}
+	return true
+}
+
+// 首页优先使用用户设置，未设置（home_path 为空）时使用角色的默认路由
+func homePath(user *entity.User, roles []*entity.Role) string {
+	if user.HomePath != nil && *user.HomePath != "" {
+		return *user.HomePath
+	}
+	for _, r := range roles {
+		if r.DefaultRouter != nil && *r.DefaultRouter != "" {
+			return *r.DefaultRouter
+		}
+	}
+	return defaultHomePath
+}
+
+// 菜单树转换为前端路由树
+func toRoutes(menus []*entity.Menu) []*menuDto.RouteResp {
+	routes := make([]*menuDto.RouteResp, 0, len(menus))
+	for _, m := range menus {
+		route := &menuDto.RouteResp{
+			Name:      m.Name,
+			Path:      deref(m.Path),
+			Component: component(m),
+			Redirect:  deref(m.Redirect),
+			Meta: menuDto.RouteMeta{
+				Title:              m.Title,
+				Icon:               deref(m.Icon),
+				OrderNo:            m.Sort,
+				HideMenu:           isYes(m.IsHidden),
+				HideTab:            isYes(m.HideTab),
+				HideBreadcrumb:     isYes(m.HideBreadcrumb),
+				HideChildrenInMenu: isYes(m.HideChildrenInMenu),
+				IgnoreKeepAlive:    !isYes(m.IsCache),
+				Affix:              isYes(m.IsAffix),
+				CarryParam:         isYes(m.CarryParam),
+				FrameSrc:           deref(m.FrameSrc),
+				RealPath:           deref(m.RealPath),
+			},
+		}
+		if m.Trans != nil && *m.Trans != "" {
+			route.Meta.Title = *m.Trans
+		}
+		if m.DynamicLevel != nil {
+			route.Meta.DynamicLevel = *m.DynamicLevel
+		}
+		if len(m.Children) > 0 {
+			route.Children = toRoutes(m.Children)
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// 目录未配置组件时使用布局组件，内嵌页面未配置组件时使用 iframe 组件
+func component(m *entity.Menu) string {
+	if m.Component != nil && *m.Component != "" {
+		return *m.Component
+	}
+	if m.Type == TypeDirectory {
+		return componentLayout
+	}
+	if m.FrameSrc != nil && *m.FrameSrc != "" {
+		return componentIframe
+	}
+	return ""
+}
+
+// 开关字段 1:是 2:否
+func isYes(v *int64) bool {
+	return v != nil && *v == 1
+}
+
+func deref(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
@@ -0,0 +1,50 @@
+package menu
+
+import (
+	"context"
+	menuDto "simple/internal/types/dto/menu"
+	"simple/internal/types/entity"
+)
+
+/*
+   @NAME    : service
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/19 20:40
+*/
+
+type (
+	IMenuService interface {
+		// CreateMenu 创建菜单
+		CreateMenu(ctx context.Context, req *menuDto.CreateMenuReq) error
+		// UpdateMenu 更新菜单，更换上级菜单时同步调整子菜单层级
+		UpdateMenu(ctx context.Context, req *menuDto.UpdateMenuReq) error
+		// DeleteMenu 删除菜单，存在子菜单或被角色使用时拒绝删除
+		DeleteMenu(ctx context.Context, req *menuDto.DeleteMenuReq) error
+		// GetMenu 获取菜单
+		GetMenu(ctx context.Context, req *menuDto.GetMenuReq) (*entity.Menu, error)
+		// TreeMenu 完整菜单树，包含按钮
+		TreeMenu(ctx context.Context) ([]*entity.Menu, error)
+		// UserRoutes 当前用户的前端路由、按钮权限与首页
+		UserRoutes(ctx context.Context, userID int64) (*menuDto.UserRoutesResp, error)
+	}
+)
+
+// 菜单类型
+const (
+	TypeDirectory = 0 // 目录
+	TypeMenu      = 1 // 菜单
+	TypeButton    = 2 // 按钮
+)
+
+var (
+	localMenu IMenuService
+)
+
+// Menu 获取菜单服务实例
+func Menu() IMenuService {
+	if localMenu == nil {
+		localMenu = newLogic()
+	}
+	return localMenu
+}
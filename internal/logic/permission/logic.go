@@ -0,0 +1,163 @@
+package permission
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"simple/internal/global"
+	"simple/internal/types/query"
+	"simple/pkg/cache"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"slices"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : logic
+   @author  : 清风
+   @desc    : 用户 -> 角色 -> 菜单 -> 权限标识，结果按用户缓存
+   @time    : 2025/3/21 20:30
+*/
+
+type logic struct{}
+
+func newLogic() *logic {
+	return &logic{}
+}
+
+// 用户权限缓存键
+func cacheKey(userID int64) string {
+	return global.Cfg.Auth.Permission.Prefix + "user:" + strconv.FormatInt(userID, 10)
+}
+
+// UserPermissions 获取用户的权限标识
+func (s *logic) UserPermissions(ctx context.Context, userID int64) (*Permissions, error) {
+	client := cache.Client()
+	key := cacheKey(userID)
+
+	// 1. 读取缓存，缓存异常时回源数据库
+	data, err := client.Get(ctx, key)
+	if err == nil {
+		perms := &Permissions{}
+		if err = json.Unmarshal([]byte(data), perms); err == nil {
+			return perms, nil
+		}
+		logger.WarnContext(ctx, "解析权限缓存失败", zap.String("key", key), zap.Error(err))
+	} else if !errors.Is(err, redis.Nil) {
+		logger.ErrorContext(ctx, "读取权限缓存失败", zap.String("key", key), zap.Error(err))
+	}
+
+	// 2. 查询数据库
+	perms, err := s.load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 写入缓存，失败不影响本次结果
+	if b, err := json.Marshal(perms); err == nil {
+		if err = client.Set(ctx, key, b, global.Cfg.Auth.Permission.CacheTTL); err != nil {
+			logger.ErrorContext(ctx, "写入权限缓存失败", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return perms, nil
+}
+
+// HasPermission 判断用户是否同时拥有全部权限标识
+func (s *logic) HasPermission(ctx context.Context, userID int64, codes ...string) (bool, error) {
+	perms, err := s.UserPermissions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return perms.Has(codes...), nil
+}
+
+// 从数据库加载用户权限，只统计启用的角色与菜单
+func (s *logic) load(ctx context.Context, userID int64) (*Permissions, error) {
+	role, userRole := query.Role, query.UserRole
+	menu, roleMenu := query.Menu, query.RoleMenu
+	perms := &Permissions{Roles: make([]string, 0), Codes: make([]string, 0)}
+
+	// 1. 用户的角色
+	roles, err := role.WithContext(ctx).
+		Join(userRole, userRole.RoleID.EqCol(role.ID)).
+		Where(userRole.UserID.Eq(userID), role.Status.Eq(1)).
+		Select(role.ID, role.Code).
+		Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询用户角色失败", zap.Int64("uid", userID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	if len(roles) == 0 {
+		return perms, nil
+	}
+
+	roleIds := make([]int64, 0, len(roles))
+	for _, r := range roles {
+		roleIds = append(roleIds, r.ID)
+		perms.Roles = append(perms.Roles, r.Code)
+		if r.Code == consts.SuperAdminRoleCode {
+			perms.SuperAdmin = true
+		}
+	}
+
+	// 2. 角色菜单上的权限标识
+	if err = menu.WithContext(ctx).
+		Distinct(menu.Permission).
+		Join(roleMenu, roleMenu.MenuID.EqCol(menu.ID)).
+		Where(roleMenu.RoleID.In(roleIds...), menu.Status.Eq(1), menu.Permission.IsNotNull(), menu.Permission.Neq("")).
+		Pluck(menu.Permission, &perms.Codes); err != nil {
+		logger.ErrorContext(ctx, "查询用户权限标识失败", zap.Int64("uid", userID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	slices.Sort(perms.Codes)
+	return perms, nil
+}
+
+// InvalidateUsers 清除用户的权限缓存
+func (s *logic) InvalidateUsers(ctx context.Context, userIds ...int64) {
+	if len(userIds) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(userIds))
+	for _, id := range userIds {
+		keys = append(keys, cacheKey(id))
+	}
+	if err := cache.Client().Del(ctx, keys...); err != nil {
+		logger.ErrorContext(ctx, "清除权限缓存失败", zap.Int64s("userIds", userIds), zap.Error(err))
+	}
+}
+
+// InvalidateRoles 清除角色关联用户的权限缓存
+func (s *logic) InvalidateRoles(ctx context.Context, roleIds ...int64) {
+	if len(roleIds) == 0 {
+		return
+	}
+
+	var userIds []int64
+	dao := query.UserRole
+	if err := dao.WithContext(ctx).Distinct(dao.UserID).Where(dao.RoleID.In(roleIds...)).Pluck(dao.UserID, &userIds); err != nil {
+		logger.ErrorContext(ctx, "查询角色关联用户失败", zap.Int64s("roleIds", roleIds), zap.Error(err))
+		return
+	}
+	s.InvalidateUsers(ctx, userIds...)
+}
+
+// InvalidateMenus 清除菜单关联用户的权限缓存
+func (s *logic) InvalidateMenus(ctx context.Context, menuIds ...int64) {
+	if len(menuIds) == 0 {
+		return
+	}
+
+	var roleIds []int64
+	dao := query.RoleMenu
+	if err := dao.WithContext(ctx).Distinct(dao.RoleID).Where(dao.MenuID.In(menuIds...)).Pluck(dao.RoleID, &roleIds); err != nil {
+		logger.ErrorContext(ctx, "查询菜单关联角色失败", zap.Int64s("menuIds", menuIds), zap.Error(err))
+		return
+	}
+	s.InvalidateRoles(ctx, roleIds...)
+}
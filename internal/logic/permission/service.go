@@ -0,0 +1,60 @@
+package permission
+
+import (
+	"context"
+	"slices"
+)
+
+/*
+   @NAME    : service
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/21 20:20
+*/
+
+type (
+	IPermissionService interface {
+		// UserPermissions 获取用户的权限标识，优先读取缓存
+		UserPermissions(ctx context.Context, userID int64) (*Permissions, error)
+		// HasPermission 判断用户是否同时拥有全部权限标识
+		HasPermission(ctx context.Context, userID int64, codes ...string) (bool, error)
+		// InvalidateUsers 用户角色变更后清除用户的权限缓存
+		InvalidateUsers(ctx context.Context, userIds ...int64)
+		// InvalidateRoles 角色或角色菜单变更后清除关联用户的权限缓存
+		InvalidateRoles(ctx context.Context, roleIds ...int64)
+		// InvalidateMenus 菜单变更后清除关联用户的权限缓存
+		InvalidateMenus(ctx context.Context, menuIds ...int64)
+	}
+)
+
+// Permissions 用户权限
+type Permissions struct {
+	SuperAdmin bool     `json:"super_admin"` // 是否超级管理员，拥有全部权限
+	Roles      []string `json:"roles"`       // 启用的角色编码
+	Codes      []string `json:"codes"`       // 启用菜单的权限标识
+}
+
+// Has 判断是否同时拥有全部权限标识
+func (p *Permissions) Has(codes ...string) bool {
+	if p.SuperAdmin {
+		return true
+	}
+	for _, code := range codes {
+		if !slices.Contains(p.Codes, code) {
+			return false
+		}
+	}
+	return true
+}
+
+var (
+	localPermission IPermissionService
+)
+
+// Permission 获取权限服务实例
+func Permission() IPermissionService {
+	if localPermission == nil {
+		localPermission = newLogic()
+	}
+	return localPermission
+}
@@ -0,0 +1,313 @@
+package position
+
+import (
+	"context"
+	"errors"
+	"simple/internal/global"
+	"simple/internal/logic/datascope"
+	"simple/internal/logic/department"
+	posDto "simple/internal/types/dto/position"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"simple/pkg/resp"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : logic
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/18 20:40
+*/
+
+type logic struct{}
+
+func newLogic() *logic {
+	return &logic{}
+}
+
+// CreatePosition 创建岗位
+func (s *logic) CreatePosition(ctx context.Context, req *posDto.CreatePositionReq) error {
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		// 1. 检查部门、名称与编码
+		if err := checkDepartment(ctx, tx, scope, req.DepartmentID); err != nil {
+			return err
+		}
+		if err := checkUnique(ctx, tx, 0, req.DepartmentID, req.Name, req.Code); err != nil {
+			return err
+		}
+
+		// 2. 创建岗位
+		p := &entity.Position{
+			DepartmentID: req.DepartmentID,
+			Name:         req.Name,
+			Code:         req.Code,
+			Sort:         req.Sort,
+			Status:       req.Status,
+			Remark:       req.Remark,
+		}
+		if err := tx.Position.WithContext(ctx).Create(p); err != nil {
+			logger.ErrorContext(ctx, "创建岗位失败", zap.Any("position", p), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// UpdatePosition 更新岗位
+func (s *logic) UpdatePosition(ctx context.Context, req *posDto.UpdatePositionReq) error {
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.Position
+		do := dao.WithContext(ctx)
+
+		// 1. 检查岗位是否存在，数据范围外的岗位视为不存在
+		if _, err := findPosition(ctx, tx, scope, req.ID); err != nil {
+			return err
+		}
+
+		// 2. 检查部门、名称与编码
+		if err := checkDepartment(ctx, tx, scope, req.DepartmentID); err != nil {
+			return err
+		}
+		if err := checkUnique(ctx, tx, req.ID, req.DepartmentID, req.Name, req.Code); err != nil {
+			return err
+		}
+
+		// 3. 更新岗位，部门为空时需要显式置空
+		p := &entity.Position{
+			Name:   req.Name,
+			Code:   req.Code,
+			Sort:   req.Sort,
+			Status: req.Status,
+			Remark: req.Remark,
+		}
+		if _, err := do.Where(dao.ID.Eq(req.ID)).Updates(p); err != nil {
+			logger.ErrorContext(ctx, "更新岗位失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		dept := dao.DepartmentID.Null()
+		if req.DepartmentID != nil {
+			dept = dao.DepartmentID.Value(*req.DepartmentID)
+		}
+		if _, err := do.Where(dao.ID.Eq(req.ID)).UpdateSimple(dept); err != nil {
+			logger.ErrorContext(ctx, "更新岗位部门失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// DeletePosition 批量删除岗位
+func (s *logic) DeletePosition(ctx context.Context, req *posDto.DeletePositionReq) error {
+	if len(req.Ids) == 0 {
+		return nil
+	}
+
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.Position
+		do := dao.WithContext(ctx)
+
+		// 1. 检查岗位是否都存在，数据范围外的岗位视为不存在
+		count, err := do.Scopes(scope.Filter(dao.DepartmentID, nil)).Where(dao.ID.In(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询岗位失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if int(count) != len(req.Ids) {
+			return consts.ErrPositionNotFound
+		}
+
+		// 2. 岗位下存在用户时拒绝删除
+		count, err = tx.User.WithContext(ctx).Where(tx.User.PositionID.In(req.Ids...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询岗位用户失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count > 0 {
+			return consts.ErrPositionHasUsers
+		}
+
+		// 3. 软删除岗位
+		if _, err = do.Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
+			logger.ErrorContext(ctx, "删除岗位失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+}
+
+// GetPosition 获取岗位
+func (s *logic) GetPosition(ctx context.Context, req *posDto.GetPositionReq) (*entity.Position, error) {
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p, err := findPosition(ctx, query.Q, scope, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.DepartmentID != nil {
+		dept, err := query.Department.WithContext(ctx).Where(query.Department.ID.Eq(*p.DepartmentID)).First()
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.ErrorContext(ctx, "查询岗位部门失败", zap.Int64("id", req.ID), zap.Error(err))
+			return nil, consts.ErrServer
+		}
+		p.Department = dept
+	}
+	return p, nil
+}
+
+// ListPosition 岗位列表
+func (s *logic) ListPosition(ctx context.Context, req *posDto.ListPositionReq) (*resp.PageResp, error) {
+	dao := query.Position
+
+	// 数据范围，岗位没有归属用户
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := dao.WithContext(ctx).Scopes(scope.Filter(dao.DepartmentID, nil))
+
+	// 条件查询
+	if req.DepartmentID != nil {
+		ids, err := department.Department().SubtreeIds(ctx, *req.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where(dao.DepartmentID.In(ids...))
+	}
+	if req.Name != nil {
+		q = q.Where(dao.Name.Like("%" + *req.Name + "%"))
+	}
+	if req.Code != nil {
+		q = q.Where(dao.Code.Like("%" + *req.Code + "%"))
+	}
+	if req.Status != nil {
+		q = q.Where(dao.Status.Eq(*req.Status))
+	}
+
+	// 分页查询
+	result, count, err := q.Order(dao.Sort, dao.ID.Desc()).FindByPage((req.Page-1)*req.Size, req.Size)
+	if err != nil {
+		logger.ErrorContext(ctx, "查询岗位列表失败", zap.Any("req", req), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	return &resp.PageResp{
+		Total: count,
+		List:  result,
+	}, nil
+}
+
+// ListPositionItem 岗位名列表
+func (s *logic) ListPositionItem(ctx context.Context, req *posDto.ListPositionItemReq) ([]*posDto.ListPositionItemResp, error) {
+	dao := query.Position
+
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := dao.WithContext(ctx).Scopes(scope.Filter(dao.DepartmentID, nil)).
+		Where(dao.Status.Eq(1)) // 只查询启用的岗位
+
+	if req.DepartmentID != nil {
+		ids, err := department.Department().SubtreeIds(ctx, *req.DepartmentID)
+		if err != nil {
+			return nil, err
+		}
+		q = q.Where(dao.DepartmentID.In(ids...))
+	}
+
+	var res []*posDto.ListPositionItemResp
+	if err := q.Order(dao.Sort).Scan(&res); err != nil {
+		logger.ErrorContext(ctx, "查询岗位列表失败", zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return res, nil
+}
+
+// 查询数据范围内的单个岗位
+func findPosition(ctx context.Context, q *query.Query, scope *datascope.Scope, id int64) (*entity.Position, error) {
+	dao := q.Position
+	p, err := dao.WithContext(ctx).Scopes(scope.Filter(dao.DepartmentID, nil)).Where(dao.ID.Eq(id)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, consts.ErrPositionNotFound
+		}
+		logger.ErrorContext(ctx, "查询岗位失败", zap.Int64("id", id), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return p, nil
+}
+
+// 检查所属部门是否存在，数据范围外的部门视为不存在，不属于任何部门的岗位只有不限制范围时可以设置
+func checkDepartment(ctx context.Context, tx *query.Query, scope *datascope.Scope, departmentID *int64) error {
+	if !scope.AllowDepartment(departmentID) {
+		return consts.ErrDepartmentNotFound
+	}
+	if departmentID == nil {
+		return nil
+	}
+	count, err := tx.Department.WithContext(ctx).Where(tx.Department.ID.Eq(*departmentID)).Count()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询部门失败", zap.Int64("id", *departmentID), zap.Error(err))
+		return consts.ErrServer
+	}
+	if count == 0 {
+		return consts.ErrDepartmentNotFound
+	}
+	return nil
+}
+
+// 检查同部门岗位名称与全局岗位编码是否重复，excludeID 为当前更新的岗位
+func checkUnique(ctx context.Context, tx *query.Query, excludeID int64, departmentID *int64, name, code string) error {
+	dao := tx.Position
+
+	// 同部门下岗位名称不能重复
+	do := dao.WithContext(ctx).Where(dao.Name.Eq(name), dao.ID.Neq(excludeID))
+	if departmentID == nil {
+		do = do.Where(dao.DepartmentID.IsNull())
+	} else {
+		do = do.Where(dao.DepartmentID.Eq(*departmentID))
+	}
+	count, err := do.Count()
+	if err != nil {
+		logger.ErrorContext(ctx, "检查岗位名称是否重复失败", zap.String("name", name), zap.Error(err))
+		return consts.ErrServer
+	}
+	if count > 0 {
+		return consts.ErrPositionNameExists
+	}
+
+	// 编码为唯一索引，已删除的岗位同样占用
+	count, err = dao.WithContext(ctx).Unscoped().Where(dao.Code.Eq(code), dao.ID.Neq(excludeID)).Count()
+	if err != nil {
+		logger.ErrorContext(ctx, "检查岗位编码是否重复失败", zap.String("code", code), zap.Error(err))
+		return consts.ErrServer
+	}
+	if count > 0 {
+		return consts.ErrPositionCodeExists
+	}
+	return nil
+}
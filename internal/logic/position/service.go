@@ -0,0 +1,44 @@
+package position
+
+import (
+	"context"
+	posDto "simple/internal/types/dto/position"
+	"simple/internal/types/entity"
+	"simple/pkg/resp"
+)
+
+/*
+   @NAME    : service
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/18 20:35
+*/
+
+type (
+	IPositionService interface {
+		// CreatePosition 创建岗位
+		CreatePosition(ctx context.Context, req *posDto.CreatePositionReq) error
+		// UpdatePosition 更新岗位
+		UpdatePosition(ctx context.Context, req *posDto.UpdatePositionReq) error
+		// DeletePosition 删除岗位，岗位下存在用户时拒绝删除
+		DeletePosition(ctx context.Context, req *posDto.DeletePositionReq) error
+		// GetPosition 获取岗位
+		GetPosition(ctx context.Context, req *posDto.GetPositionReq) (*entity.Position, error)
+		// ListPosition 岗位列表，按部门过滤时包含下级部门
+		ListPosition(ctx context.Context, req *posDto.ListPositionReq) (*resp.PageResp, error)
+		// ListPositionItem 岗位名列表用于创建用户选择岗位
+		ListPositionItem(ctx context.Context, req *posDto.ListPositionItemReq) ([]*posDto.ListPositionItemResp, error)
+	}
+)
+
+var (
+	localPosition IPositionService
+)
+
+// Position 获取岗位服务实例
+func Position() IPositionService {
+	if localPosition == nil {
+		localPosition = newLogic()
+	}
+	return localPosition
+}
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"simple/internal/global"
+	"simple/internal/logic/builtin"
+	"simple/internal/logic/datascope"
+	"simple/internal/logic/permission"
 	roleDto "simple/internal/types/dto/role"
 	"simple/internal/types/entity"
 	"simple/internal/types/query"
 	"simple/pkg/consts"
 	"simple/pkg/logger"
 	"simple/pkg/resp"
+	"slices"
 
 	"go.uber.org/zap"
 	"gorm.io/gorm"
@@ -38,7 +42,7 @@ func (s *logic) CreateRole(ctx context.Context, req *roleDto.CreateRoleReq) erro
 		// 1. 检查角色名称是否已存在
 		r, err := do.Where(dao.Name.Eq(req.Name)).Select(dao.ID).First()
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			logger.Error("检查角色名称是否存在失败", zap.String("name", req.Name), zap.Error(err))
+			logger.ErrorContext(ctx, "检查角色名称是否存在失败", zap.String("name", req.Name), zap.Error(err))
 			return consts.ErrServer
 		}
 
@@ -49,7 +53,7 @@ func (s *logic) CreateRole(ctx context.Context, req *roleDto.CreateRoleReq) erro
 		// 2. 检查角色编码是否已存在
 		r, err = do.Where(dao.Code.Eq(req.Code)).Select(dao.ID).First()
 		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-			logger.Error("检查角色编码是否存在失败", zap.String("code", req.Code), zap.Error(err))
+			logger.ErrorContext(ctx, "检查角色编码是否存在失败", zap.String("code", req.Code), zap.Error(err))
 			return consts.ErrServer
 		}
 		if r.ID != 0 {
@@ -64,22 +68,24 @@ func (s *logic) CreateRole(ctx context.Context, req *roleDto.CreateRoleReq) erro
 			Status:        req.Status,
 			Remark:        req.Remark,
 			Sort:          req.Sort,
+			DataScope:     req.DataScope,
 		}
 
 		// 使用事务中的DB进行创建
 		if err := do.Create(r); err != nil {
-			logger.Error("创建角色失败", zap.Any("role", r), zap.Error(err))
+			logger.ErrorContext(ctx, "创建角色失败", zap.Any("role", r), zap.Error(err))
 			return consts.ErrServer
 		}
 
-		return nil
+		// 4. 自定义数据范围的部门
+		return replaceDepartments(ctx, tx, r.ID, req.DataScope, req.DepartmentIds)
 	})
 }
 
 // UpdateRole 更新角色
 func (s *logic) UpdateRole(ctx context.Context, req *roleDto.UpdateRoleReq) error {
 	// 使用事务进行所有操作，确保原子性
-	return global.Query.Transaction(func(tx *query.Query) error {
+	err := global.Query.Transaction(func(tx *query.Query) error {
 		dao := tx.Role
 		do := dao.WithContext(ctx)
 
@@ -89,15 +95,30 @@ func (s *logic) UpdateRole(ctx context.Context, req *roleDto.UpdateRoleReq) erro
 			if err == gorm.ErrRecordNotFound {
 				return consts.ErrRoleNotFound
 			}
-			logger.Error("查询角色失败", zap.Int64("id", req.ID), zap.Error(err))
+			logger.ErrorContext(ctx, "查询角色失败", zap.Int64("id", req.ID), zap.Error(err))
 			return consts.ErrServer
 		}
 
+		// 内置角色不允许修改编码、状态与数据范围，未设置数据范围视为全部数据
+		var changed []builtin.Field
+		if oldRole.Code != req.Code {
+			changed = append(changed, builtin.FieldCode)
+		}
+		if req.Status != nil && (oldRole.Status == nil || *oldRole.Status != *req.Status) {
+			changed = append(changed, builtin.FieldStatus)
+		}
+		if req.DataScope != nil && *req.DataScope != effectiveScope(oldRole.DataScope) {
+			changed = append(changed, builtin.FieldDataScope)
+		}
+		if err := builtin.Guard(builtin.KindRole, oldRole.Code, changed...); err != nil {
+			return err
+		}
+
 		// 2. 检查角色名称是否与其他角色重复
 		if oldRole.Name != req.Name {
 			r, err := do.Where(dao.Name.Eq(req.Name)).Where(dao.ID.Neq(req.ID)).Select(dao.ID).First()
 			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-				logger.Error("检查角色名称是否重复失败", zap.String("name", req.Name), zap.Error(err))
+				logger.ErrorContext(ctx, "检查角色名称是否重复失败", zap.String("name", req.Name), zap.Error(err))
 				return consts.ErrServer
 			}
 			if r.ID != 0 {
@@ -109,7 +130,7 @@ func (s *logic) UpdateRole(ctx context.Context, req *roleDto.UpdateRoleReq) erro
 		if oldRole.Code != req.Code {
 			r, err := do.Where(dao.Code.Eq(req.Code)).Where(dao.ID.Neq(req.ID)).Select(dao.ID).First()
 			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-				logger.Error("检查角色编码是否重复失败", zap.String("code", req.Code), zap.Error(err))
+				logger.ErrorContext(ctx, "检查角色编码是否重复失败", zap.String("code", req.Code), zap.Error(err))
 				return consts.ErrServer
 			}
 			if r.ID != 0 {
@@ -126,17 +147,26 @@ func (s *logic) UpdateRole(ctx context.Context, req *roleDto.UpdateRoleReq) erro
 			Status:        req.Status,
 			Remark:        req.Remark,
 			Sort:          req.Sort,
+			DataScope:     req.DataScope,
 		}
 
 		// 使用事务中的DB进行更新
 		_, err = do.Where(dao.ID.Eq(req.ID)).Updates(r)
 		if err != nil {
-			logger.Error("更新角色失败", zap.Any("role", r), zap.Error(err))
+			logger.ErrorContext(ctx, "更新角色失败", zap.Any("role", r), zap.Error(err))
 			return consts.ErrServer
 		}
 
-		return nil
+		// 5. 数据范围未传入时保持原有部门
+		if req.DataScope == nil {
+			return nil
+		}
+		return replaceDepartments(ctx, tx, req.ID, req.DataScope, req.DepartmentIds)
 	})
+	if err == nil {
+		permission.Permission().InvalidateRoles(ctx, req.ID)
+	}
+	return err
 }
 
 // DeleteRole 批量删除角色
@@ -145,15 +175,18 @@ func (s *logic) DeleteRole(ctx context.Context, req *roleDto.DeleteRoleReq) erro
 		return nil
 	}
 
+	// 删除前记录关联用户，提交后清除其权限缓存
+	var userIds []int64
+
 	// 使用事务进行所有操作，确保数据一致性
-	return global.Query.Transaction(func(tx *query.Query) error {
+	err := global.Query.Transaction(func(tx *query.Query) error {
 		dao := tx.Role
 		do := dao.WithContext(ctx)
 
 		// 1. 检查角色是否存在并且不包含超级管理员
 		roles, err := do.Where(dao.ID.In(req.Ids...)).Find()
 		if err != nil {
-			logger.Error("查询角色失败", zap.Any("Ids", req.Ids), zap.Error(err))
+			logger.ErrorContext(ctx, "查询角色失败", zap.Any("Ids", req.Ids), zap.Error(err))
 			return consts.ErrServer
 		}
 
@@ -162,30 +195,55 @@ func (s *logic) DeleteRole(ctx context.Context, req *roleDto.DeleteRoleReq) erro
 			return consts.ErrRoleNotFound
 		}
 
-		// 检查是否包含超级管理员
+		// 检查是否包含超级管理员等内置角色
 		for _, role := range roles {
-			if role.Code == SuperAdminCode {
-				return consts.ErrRoleSuperAdmin
+			if err := builtin.Guard(builtin.KindRole, role.Code, builtin.FieldDelete); err != nil {
+				return err
 			}
 		}
 
 		// 2. 删除角色关联的用户信息
+		err = tx.UserRole.WithContext(ctx).Distinct(tx.UserRole.UserID).
+			Where(tx.UserRole.RoleID.In(req.Ids...)).Pluck(tx.UserRole.UserID, &userIds)
+		if err != nil {
+			logger.ErrorContext(ctx, "查询角色关联用户失败", zap.Any("roleIds", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
 		_, err = tx.UserRole.WithContext(ctx).
 			Where(query.UserRole.RoleID.In(req.Ids...)).Delete()
 		if err != nil {
-			logger.Error("删除角色关联用户失败", zap.Any("roleIds", req.Ids), zap.Error(err))
+			logger.ErrorContext(ctx, "删除角色关联用户失败", zap.Any("roleIds", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		// 3. 删除角色关联的菜单与数据范围
+		_, err = tx.RoleMenu.WithContext(ctx).
+			Where(tx.RoleMenu.RoleID.In(req.Ids...)).Delete()
+		if err != nil {
+			logger.ErrorContext(ctx, "删除角色关联菜单失败", zap.Any("roleIds", req.Ids), zap.Error(err))
 			return consts.ErrServer
 		}
 
-		// 3. 软删除角色
+		_, err = tx.RoleDepartment.WithContext(ctx).
+			Where(tx.RoleDepartment.RoleID.In(req.Ids...)).Delete()
+		if err != nil {
+			logger.ErrorContext(ctx, "删除角色数据范围失败", zap.Any("roleIds", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		// 4. 软删除角色
 		_, err = do.Where(dao.ID.In(req.Ids...)).Delete()
 		if err != nil {
-			logger.Error("删除角色失败", zap.Any("roleIds", req.Ids), zap.Error(err))
+			logger.ErrorContext(ctx, "删除角色失败", zap.Any("roleIds", req.Ids), zap.Error(err))
 			return consts.ErrServer
 		}
 
 		return nil
 	})
+	if err == nil {
+		permission.Permission().InvalidateUsers(ctx, userIds...)
+	}
+	return err
 }
 
 // GetRole 获取角色
@@ -195,9 +253,22 @@ func (s *logic) GetRole(ctx context.Context, req *roleDto.GetRoleReq) (*entity.R
 		if err == gorm.ErrRecordNotFound {
 			return nil, consts.ErrRoleNotFound
 		}
-		logger.Error("查询角色失败", zap.Int64("id", req.ID), zap.Error(err))
+		logger.ErrorContext(ctx, "查询角色失败", zap.Int64("id", req.ID), zap.Error(err))
 		return nil, consts.ErrServer
 	}
+
+	// 自定义数据范围的部门
+	if role.DataScope != nil && *role.DataScope == datascope.ScopeCustom {
+		dept, roleDept := query.Department, query.RoleDepartment
+		role.Departments, err = dept.WithContext(ctx).
+			Join(roleDept, roleDept.DepartmentID.EqCol(dept.ID)).
+			Where(roleDept.RoleID.Eq(role.ID)).
+			Find()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询角色数据范围失败", zap.Int64("id", req.ID), zap.Error(err))
+			return nil, consts.ErrServer
+		}
+	}
 	return role, nil
 }
 
@@ -220,7 +291,7 @@ func (s *logic) ListRole(ctx context.Context, req *roleDto.ListRoleReq) (*resp.P
 	result, count, err := q.Order(query.Role.Sort, query.Role.ID.Desc()).
 		FindByPage((req.Page-1)*req.Size, req.Size)
 	if err != nil {
-		logger.Error("查询角色列表失败", zap.Any("req", req), zap.Error(err))
+		logger.ErrorContext(ctx, "查询角色列表失败", zap.Any("req", req), zap.Error(err))
 		return nil, consts.ErrServer
 	}
 
@@ -237,8 +308,125 @@ func (s *logic) ListRoleItem(ctx context.Context) ([]*roleDto.ListRoleItemResp,
 		Where(query.Role.Status.Eq(1)). // 只查询启用的角色
 		Order(query.Role.Sort).
 		Scan(&res); err != nil {
-		logger.Error("查询角色列表失败", zap.Error(err))
+		logger.ErrorContext(ctx, "查询角色列表失败", zap.Error(err))
 		return nil, consts.ErrServer
 	}
 	return res, nil
 }
+
+// AssignMenus 分配角色菜单
+func (s *logic) AssignMenus(ctx context.Context, req *roleDto.AssignMenusReq) error {
+	menuIds := slices.Compact(slices.Sorted(slices.Values(req.MenuIds)))
+
+	err := global.Query.Transaction(func(tx *query.Query) error {
+		// 1. 检查角色是否存在，内置角色不允许修改菜单
+		role, err := tx.Role.WithContext(ctx).Where(tx.Role.ID.Eq(req.RoleID)).Select(tx.Role.ID, tx.Role.Code).First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return consts.ErrRoleNotFound
+			}
+			logger.ErrorContext(ctx, "查询角色失败", zap.Int64("id", req.RoleID), zap.Error(err))
+			return consts.ErrServer
+		}
+		if err = builtin.Guard(builtin.KindRole, role.Code, builtin.FieldMenus); err != nil {
+			return err
+		}
+
+		// 2. 检查菜单是否都存在
+		if len(menuIds) > 0 {
+			count, err := tx.Menu.WithContext(ctx).Where(tx.Menu.ID.In(menuIds...)).Count()
+			if err != nil {
+				logger.ErrorContext(ctx, "查询菜单失败", zap.Any("menuIds", menuIds), zap.Error(err))
+				return consts.ErrServer
+			}
+			if int(count) != len(menuIds) {
+				return consts.ErrMenuNotFound
+			}
+		}
+
+		// 3. 删除原有关联
+		dao := tx.RoleMenu
+		if _, err := dao.WithContext(ctx).Where(dao.RoleID.Eq(req.RoleID)).Delete(); err != nil {
+			logger.ErrorContext(ctx, "删除角色菜单失败", zap.Int64("roleId", req.RoleID), zap.Error(err))
+			return consts.ErrServer
+		}
+		if len(menuIds) == 0 {
+			return nil
+		}
+
+		// 4. 写入新关联
+		rows := make([]*entity.RoleMenu, 0, len(menuIds))
+		for _, menuID := range menuIds {
+			rows = append(rows, &entity.RoleMenu{RoleID: req.RoleID, MenuID: menuID})
+		}
+		if err := dao.WithContext(ctx).Create(rows...); err != nil {
+			logger.ErrorContext(ctx, "创建角色菜单失败", zap.Int64("roleId", req.RoleID), zap.Any("menuIds", menuIds), zap.Error(err))
+			return consts.ErrServer
+		}
+		return nil
+	})
+	if err == nil {
+		permission.Permission().InvalidateRoles(ctx, req.RoleID)
+	}
+	return err
+}
+
+// GetRoleMenus 获取角色已分配的菜单ID
+func (s *logic) GetRoleMenus(ctx context.Context, req *roleDto.GetRoleReq) ([]int64, error) {
+	dao := query.RoleMenu
+	ids := make([]int64, 0)
+	if err := dao.WithContext(ctx).Where(dao.RoleID.Eq(req.ID)).Pluck(dao.MenuID, &ids); err != nil {
+		logger.ErrorContext(ctx, "查询角色菜单失败", zap.Int64("id", req.ID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+	return ids, nil
+}
+
+// 覆盖角色自定义数据范围的部门，非自定义范围时清空
+func replaceDepartments(ctx context.Context, tx *query.Query, roleID int64, dataScope *int64, departmentIds []int64) error {
+	if dataScope == nil || *dataScope != datascope.ScopeCustom {
+		departmentIds = nil
+	}
+	departmentIds = slices.Compact(slices.Sorted(slices.Values(departmentIds)))
+
+	// 1. 检查部门是否都存在
+	if len(departmentIds) > 0 {
+		count, err := tx.Department.WithContext(ctx).Where(tx.Department.ID.In(departmentIds...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询部门失败", zap.Int64s("departmentIds", departmentIds), zap.Error(err))
+			return consts.ErrServer
+		}
+		if int(count) != len(departmentIds) {
+			return consts.ErrDepartmentNotFound
+		}
+	}
+
+	// 2. 删除原有关联
+	dao := tx.RoleDepartment
+	if _, err := dao.WithContext(ctx).Where(dao.RoleID.Eq(roleID)).Delete(); err != nil {
+		logger.ErrorContext(ctx, "删除角色数据范围失败", zap.Int64("roleId", roleID), zap.Error(err))
+		return consts.ErrServer
+	}
+	if len(departmentIds) == 0 {
+		return nil
+	}
+
+	// 3. 写入新关联
+	rows := make([]*entity.RoleDepartment, 0, len(departmentIds))
+	for _, departmentID := range departmentIds {
+		rows = append(rows, &entity.RoleDepartment{RoleID: roleID, DepartmentID: departmentID})
+	}
+	if err := dao.WithContext(ctx).Create(rows...); err != nil {
+		logger.ErrorContext(ctx, "创建角色数据范围失败", zap.Int64("roleId", roleID), zap.Error(err))
+		return consts.ErrServer
+	}
+	return nil
+}
+
+// 角色实际生效的数据范围，未设置时为全部数据
+func effectiveScope(dataScope *int64) int64 {
+	if dataScope == nil {
+		return datascope.ScopeAll
+	}
+	return *dataScope
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	roleDto "simple/internal/types/dto/role"
 	"simple/internal/types/entity"
+	"simple/pkg/consts"
 	"simple/pkg/resp"
 )
 
@@ -28,11 +29,15 @@ type (
 		ListRole(ctx context.Context, req *roleDto.ListRoleReq) (*resp.PageResp, error)
 		// ListRoleItem 角色名列表用于创建管理员分配角色
 		ListRoleItem(ctx context.Context) ([]*roleDto.ListRoleItemResp, error)
+		// AssignMenus 分配角色菜单
+		AssignMenus(ctx context.Context, req *roleDto.AssignMenusReq) error
+		// GetRoleMenus 获取角色已分配的菜单ID
+		GetRoleMenus(ctx context.Context, req *roleDto.GetRoleReq) ([]int64, error)
 	}
 )
 
 const (
-	SuperAdminCode = "super-admin"
+	SuperAdminCode = consts.SuperAdminRoleCode
 )
 
 var (
@@ -0,0 +1,496 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"simple/internal/global"
+	"simple/internal/logic/builtin"
+	"simple/internal/logic/datascope"
+	"simple/internal/logic/permission"
+	userDto "simple/internal/types/dto/user"
+	"simple/internal/types/entity"
+	"simple/internal/types/query"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"simple/pkg/password"
+	"simple/pkg/resp"
+	"slices"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+/*
+   @NAME    : logic
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/16 14:25
+*/
+
+type logic struct{}
+
+func newLogic() *logic {
+	return &logic{}
+}
+
+// CreateUser 创建用户
+func (s *logic) CreateUser(ctx context.Context, req *userDto.CreateUserReq) error {
+	// 密码哈希较耗时，放在事务外计算
+	hash, err := password.Hash(req.Password)
+	if err != nil {
+		logger.ErrorContext(ctx, "计算密码哈希失败", zap.String("username", req.Username), zap.Error(err))
+		return consts.ErrServer
+	}
+
+	// 数据范围外的部门视为不存在，未指定部门的用户只有不限制范围时可以创建
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+	if !scope.AllowDepartment(req.DepartmentID) {
+		return consts.ErrDepartmentNotFound
+	}
+
+	return global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.User
+		do := dao.WithContext(ctx)
+
+		// 1. 检查用户名是否已存在，已删除的用户仍占用唯一索引
+		u, err := do.Unscoped().Where(dao.Username.Eq(req.Username)).Select(dao.ID).First()
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.ErrorContext(ctx, "检查用户名是否存在失败", zap.String("username", req.Username), zap.Error(err))
+			return consts.ErrServer
+		}
+		if u != nil && u.ID != 0 {
+			return consts.ErrUserExists
+		}
+
+		// 2. 检查部门与岗位
+		if err = checkOrganization(ctx, tx, scope, req.DepartmentID, req.PositionID); err != nil {
+			return err
+		}
+
+		// 3. 创建用户
+		u = &entity.User{
+			UUID:         uuid.NewString(),
+			Username:     req.Username,
+			Password:     hash,
+			Name:         req.Name,
+			Nickname:     req.Nickname,
+			Email:        req.Email,
+			Mobile:       req.Mobile,
+			Avatar:       req.Avatar,
+			Status:       req.Status,
+			Remark:       req.Remark,
+			HomePath:     req.HomePath,
+			DepartmentID: req.DepartmentID,
+			PositionID:   req.PositionID,
+		}
+		if err = do.Create(u); err != nil {
+			logger.ErrorContext(ctx, "创建用户失败", zap.String("username", req.Username), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		// 4. 关联角色
+		return replaceRoles(ctx, tx, scope, u.ID, req.RoleIds)
+	})
+}
+
+// UpdateUser 更新用户
+func (s *logic) UpdateUser(ctx context.Context, req *userDto.UpdateUserReq) error {
+	var hash string
+	if req.Password != nil && *req.Password != "" {
+		var err error
+		if hash, err = password.Hash(*req.Password); err != nil {
+			logger.ErrorContext(ctx, "计算密码哈希失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+	}
+
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.User
+		do := dao.WithContext(ctx)
+
+		// 1. 检查用户是否存在，数据范围外的用户视为不存在，内置用户不允许修改状态与角色
+		old, err := do.Scopes(scope.Filter(dao.DepartmentID, &dao.ID)).Where(dao.ID.Eq(req.ID)).Select(dao.ID, dao.Username, dao.Status).First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return consts.ErrUserNotFound
+			}
+			logger.ErrorContext(ctx, "查询用户失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+		if err = guardBuiltin(ctx, tx, old, req.Status, req.RoleIds); err != nil {
+			return err
+		}
+
+		// 2. 检查部门与岗位
+		if err := checkOrganization(ctx, tx, scope, req.DepartmentID, req.PositionID); err != nil {
+			return err
+		}
+
+		// 3. 更新用户
+		u := &entity.User{
+			Name:         req.Name,
+			Nickname:     req.Nickname,
+			Email:        req.Email,
+			Mobile:       req.Mobile,
+			Avatar:       req.Avatar,
+			Status:       req.Status,
+			Remark:       req.Remark,
+			HomePath:     req.HomePath,
+			DepartmentID: req.DepartmentID,
+			PositionID:   req.PositionID,
+		}
+		if _, err := do.Where(dao.ID.Eq(req.ID)).Updates(u); err != nil {
+			logger.ErrorContext(ctx, "更新用户失败", zap.Int64("id", req.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		// 4. 修改密码，同时清空旧算法的盐值
+		if hash != "" {
+			if _, err := do.Where(dao.ID.Eq(req.ID)).
+				UpdateSimple(dao.Password.Value(hash), dao.Salt.Value("")); err != nil {
+				logger.ErrorContext(ctx, "更新用户密码失败", zap.Int64("id", req.ID), zap.Error(err))
+				return consts.ErrServer
+			}
+		}
+
+		// 5. 角色列表为nil时保持原有角色
+		if req.RoleIds == nil {
+			return nil
+		}
+		return replaceRoles(ctx, tx, scope, req.ID, req.RoleIds)
+	})
+	if err == nil {
+		if req.RoleIds != nil {
+			permission.Permission().InvalidateUsers(ctx, req.ID)
+		}
+	}
+	return err
+}
+
+// DeleteUser 批量删除用户
+func (s *logic) DeleteUser(ctx context.Context, req *userDto.DeleteUserReq) error {
+	if len(req.Ids) == 0 {
+		return nil
+	}
+
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.User
+		do := dao.WithContext(ctx)
+
+		// 1. 检查用户是否都存在并且不包含内置用户，数据范围外的用户视为不存在
+		users, err := do.Scopes(scope.Filter(dao.DepartmentID, &dao.ID)).Where(dao.ID.In(req.Ids...)).Select(dao.ID, dao.Username).Find()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询用户失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+		if len(users) != len(req.Ids) {
+			return consts.ErrUserNotFound
+		}
+		for _, u := range users {
+			if err = builtin.Guard(builtin.KindUser, u.Username, builtin.FieldDelete); err != nil {
+				return err
+			}
+		}
+
+		// 2. 删除用户关联的角色
+		if _, err = tx.UserRole.WithContext(ctx).Where(tx.UserRole.UserID.In(req.Ids...)).Delete(); err != nil {
+			logger.ErrorContext(ctx, "删除用户关联角色失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		// 3. 软删除用户
+		if _, err = do.Where(dao.ID.In(req.Ids...)).Delete(); err != nil {
+			logger.ErrorContext(ctx, "删除用户失败", zap.Any("ids", req.Ids), zap.Error(err))
+			return consts.ErrServer
+		}
+
+		return nil
+	})
+	if err == nil {
+		permission.Permission().InvalidateUsers(ctx, req.Ids...)
+	}
+	return err
+}
+
+// GetUser 获取用户
+func (s *logic) GetUser(ctx context.Context, req *userDto.GetUserReq) (*entity.User, error) {
+	// 数据范围外的用户视为不存在
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dao := query.User
+	u, err := dao.WithContext(ctx).Scopes(scope.Filter(dao.DepartmentID, &dao.ID)).Where(dao.ID.Eq(req.ID)).First()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, consts.ErrUserNotFound
+		}
+		logger.ErrorContext(ctx, "查询用户失败", zap.Int64("id", req.ID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	// 部门与岗位
+	if u.DepartmentID != nil {
+		dept, err := query.Department.WithContext(ctx).Where(query.Department.ID.Eq(*u.DepartmentID)).First()
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.ErrorContext(ctx, "查询用户部门失败", zap.Int64("id", req.ID), zap.Error(err))
+			return nil, consts.ErrServer
+		}
+		u.Department = dept
+	}
+	if u.PositionID != nil {
+		pos, err := query.Position.WithContext(ctx).Where(query.Position.ID.Eq(*u.PositionID)).First()
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.ErrorContext(ctx, "查询用户岗位失败", zap.Int64("id", req.ID), zap.Error(err))
+			return nil, consts.ErrServer
+		}
+		u.Position = pos
+	}
+
+	// 角色
+	role, userRole := query.Role, query.UserRole
+	u.Roles, err = role.WithContext(ctx).
+		Join(userRole, userRole.RoleID.EqCol(role.ID)).
+		Where(userRole.UserID.Eq(u.ID)).
+		Order(role.Sort).
+		Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询用户角色失败", zap.Int64("id", req.ID), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	return u, nil
+}
+
+// ListUser 用户列表
+func (s *logic) ListUser(ctx context.Context, req *userDto.ListUserReq) (*resp.PageResp, error) {
+	dao := query.User
+
+	// 数据范围
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	q := dao.WithContext(ctx).Scopes(scope.Filter(dao.DepartmentID, &dao.ID))
+
+	// 条件查询
+	if req.DepartmentID != nil {
+		q = q.Where(dao.DepartmentID.Eq(*req.DepartmentID))
+	}
+	if req.PositionID != nil {
+		q = q.Where(dao.PositionID.Eq(*req.PositionID))
+	}
+	if req.Status != nil {
+		q = q.Where(dao.Status.Eq(*req.Status))
+	}
+	if req.Name != nil {
+		q = q.Where(dao.Name.Like("%" + *req.Name + "%"))
+	}
+	if req.Mobile != nil {
+		q = q.Where(dao.Mobile.Like(*req.Mobile + "%"))
+	}
+
+	// 分页查询
+	result, count, err := q.Order(dao.ID.Desc()).FindByPage((req.Page-1)*req.Size, req.Size)
+	if err != nil {
+		logger.ErrorContext(ctx, "查询用户列表失败", zap.Any("req", req), zap.Error(err))
+		return nil, consts.ErrServer
+	}
+
+	return &resp.PageResp{
+		Total: count,
+		List:  result,
+	}, nil
+}
+
+// AssignRoles 分配用户角色
+func (s *logic) AssignRoles(ctx context.Context, req *userDto.AssignRolesReq) error {
+	scope, err := datascope.Resolve(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = global.Query.Transaction(func(tx *query.Query) error {
+		dao := tx.User
+		// 数据范围外的用户视为不存在
+		u, err := dao.WithContext(ctx).Scopes(scope.Filter(dao.DepartmentID, &dao.ID)).Where(dao.ID.Eq(req.UserID)).Select(dao.ID, dao.Username).First()
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return consts.ErrUserNotFound
+			}
+			logger.ErrorContext(ctx, "查询用户失败", zap.Int64("id", req.UserID), zap.Error(err))
+			return consts.ErrServer
+		}
+		if err = guardBuiltin(ctx, tx, u, nil, req.RoleIds); err != nil {
+			return err
+		}
+		return replaceRoles(ctx, tx, scope, req.UserID, req.RoleIds)
+	})
+	if err == nil {
+		permission.Permission().InvalidateUsers(ctx, req.UserID)
+	}
+	return err
+}
+
+// 检查部门与岗位是否存在，数据范围外的部门视为不存在
+func checkOrganization(ctx context.Context, tx *query.Query, scope *datascope.Scope, departmentID, positionID *int64) error {
+	if departmentID != nil {
+		if !scope.AllowDepartment(departmentID) {
+			return consts.ErrDepartmentNotFound
+		}
+		count, err := tx.Department.WithContext(ctx).Where(tx.Department.ID.Eq(*departmentID)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询部门失败", zap.Int64("id", *departmentID), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count == 0 {
+			return consts.ErrDepartmentNotFound
+		}
+	}
+	if positionID != nil {
+		count, err := tx.Position.WithContext(ctx).Where(tx.Position.ID.Eq(*positionID)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询岗位失败", zap.Int64("id", *positionID), zap.Error(err))
+			return consts.ErrServer
+		}
+		if count == 0 {
+			return consts.ErrPositionNotFound
+		}
+	}
+	return nil
+}
+
+// 检查内置用户的状态与角色是否变更，status 或 roleIds 为nil表示不修改
+func guardBuiltin(ctx context.Context, tx *query.Query, u *entity.User, status *int64, roleIds []int64) error {
+	if !builtin.IsBuiltin(builtin.KindUser, u.Username) {
+		return nil
+	}
+
+	var changed []builtin.Field
+	if status != nil && (u.Status == nil || *u.Status != *status) {
+		changed = append(changed, builtin.FieldStatus)
+	}
+	if roleIds != nil {
+		var current []int64
+		dao := tx.UserRole
+		if err := dao.WithContext(ctx).Where(dao.UserID.Eq(u.ID)).Pluck(dao.RoleID, &current); err != nil {
+			logger.ErrorContext(ctx, "查询用户角色失败", zap.Int64("userId", u.ID), zap.Error(err))
+			return consts.ErrServer
+		}
+		if !slices.Equal(slices.Compact(slices.Sorted(slices.Values(roleIds))), slices.Compact(slices.Sorted(slices.Values(current)))) {
+			changed = append(changed, builtin.FieldRoles)
+		}
+	}
+	return builtin.Guard(builtin.KindUser, u.Username, changed...)
+}
+
+// 覆盖用户的角色关联
+func replaceRoles(ctx context.Context, tx *query.Query, scope *datascope.Scope, userID int64, roleIds []int64) error {
+	roleIds = slices.Compact(slices.Sorted(slices.Values(roleIds)))
+
+	// 1. 检查角色是否都存在
+	if len(roleIds) > 0 {
+		count, err := tx.Role.WithContext(ctx).Where(tx.Role.ID.In(roleIds...)).Count()
+		if err != nil {
+			logger.ErrorContext(ctx, "查询角色失败", zap.Any("roleIds", roleIds), zap.Error(err))
+			return consts.ErrServer
+		}
+		if int(count) != len(roleIds) {
+			return consts.ErrRoleNotFound
+		}
+		if err = checkRoleScope(ctx, tx, scope, userID, roleIds); err != nil {
+			return err
+		}
+	}
+
+	// 2. 删除原有关联
+	dao := tx.UserRole
+	if _, err := dao.WithContext(ctx).Where(dao.UserID.Eq(userID)).Delete(); err != nil {
+		logger.ErrorContext(ctx, "删除用户角色失败", zap.Int64("userId", userID), zap.Error(err))
+		return consts.ErrServer
+	}
+	if len(roleIds) == 0 {
+		return nil
+	}
+
+	// 3. 写入新关联
+	rows := make([]*entity.UserRole, 0, len(roleIds))
+	for _, roleID := range roleIds {
+		rows = append(rows, &entity.UserRole{UserID: userID, RoleID: roleID})
+	}
+	if err := dao.WithContext(ctx).Create(rows...); err != nil {
+		logger.ErrorContext(ctx, "创建用户角色失败", zap.Int64("userId", userID), zap.Any("roleIds", roleIds), zap.Error(err))
+		return consts.ErrServer
+	}
+	return nil
+}
+
+// 检查新增的角色是否超出当前用户的数据范围，不限制范围时不检查。
+// 受限的用户不能分配超级管理员、全部数据范围以及自定义部门超出自身范围的角色，已有的角色保持不变。
+func checkRoleScope(ctx context.Context, tx *query.Query, scope *datascope.Scope, userID int64, roleIds []int64) error {
+	if scope.All {
+		return nil
+	}
+
+	// 1. 新增的角色
+	var current []int64
+	userRole := tx.UserRole
+	if err := userRole.WithContext(ctx).Where(userRole.UserID.Eq(userID)).Pluck(userRole.RoleID, &current); err != nil {
+		logger.ErrorContext(ctx, "查询用户角色失败", zap.Int64("userId", userID), zap.Error(err))
+		return consts.ErrServer
+	}
+	added := slices.DeleteFunc(slices.Clone(roleIds), func(id int64) bool {
+		return slices.Contains(current, id)
+	})
+	if len(added) == 0 {
+		return nil
+	}
+
+	// 2. 超级管理员与全部数据范围的角色，未设置数据范围视为全部数据
+	dao := tx.Role
+	roles, err := dao.WithContext(ctx).Where(dao.ID.In(added...)).Select(dao.ID, dao.Code, dao.DataScope).Find()
+	if err != nil {
+		logger.ErrorContext(ctx, "查询角色失败", zap.Int64s("roleIds", added), zap.Error(err))
+		return consts.ErrServer
+	}
+	var custom []int64
+	for _, r := range roles {
+		if r.Code == consts.SuperAdminRoleCode || r.DataScope == nil || *r.DataScope == datascope.ScopeAll {
+			return consts.ErrRoleOutOfScope
+		}
+		if *r.DataScope == datascope.ScopeCustom {
+			custom = append(custom, r.ID)
+		}
+	}
+	if len(custom) == 0 {
+		return nil
+	}
+
+	// 3. 自定义部门需要都在数据范围内
+	var deptIds []int64
+	roleDept := tx.RoleDepartment
+	if err = roleDept.WithContext(ctx).Distinct(roleDept.DepartmentID).Where(roleDept.RoleID.In(custom...)).Pluck(roleDept.DepartmentID, &deptIds); err != nil {
+		logger.ErrorContext(ctx, "查询角色自定义部门失败", zap.Int64s("roleIds", custom), zap.Error(err))
+		return consts.ErrServer
+	}
+	for _, id := range deptIds {
+		if !scope.AllowDepartment(&id) {
+			return consts.ErrRoleOutOfScope
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package user
+
+import (
+	"context"
+	userDto "simple/internal/types/dto/user"
+	"simple/internal/types/entity"
+	"simple/pkg/resp"
+)
+
+/*
+   @NAME    : service
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/16 14:20
+*/
+
+type (
+	IUserService interface {
+		// CreateUser 创建用户
+		CreateUser(ctx context.Context, req *userDto.CreateUserReq) error
+		// UpdateUser 更新用户
+		UpdateUser(ctx context.Context, req *userDto.UpdateUserReq) error
+		// DeleteUser 删除用户
+		DeleteUser(ctx context.Context, req *userDto.DeleteUserReq) error
+		// GetUser 获取用户，包含部门、岗位与角色
+		GetUser(ctx context.Context, req *userDto.GetUserReq) (*entity.User, error)
+		// ListUser 用户列表
+		ListUser(ctx context.Context, req *userDto.ListUserReq) (*resp.PageResp, error)
+		// AssignRoles 分配用户角色
+		AssignRoles(ctx context.Context, req *userDto.AssignRolesReq) error
+	}
+)
+
+var (
+	localUser IUserService
+)
+
+// User 获取用户服务实例
+func User() IUserService {
+	if localUser == nil {
+		localUser = newLogic()
+	}
+	return localUser
+}
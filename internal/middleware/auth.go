@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"simple/pkg/consts"
+	"simple/pkg/jwt"
+	"simple/pkg/logger"
+	"simple/pkg/resp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : auth
+   @author  : 清风
+   @desc    : JWT 认证中间件
+   @time    : 2025/3/11 22:30
+*/
+
+const (
+	// ClaimsKey 令牌声明在 gin.Context 中的键
+	ClaimsKey = "claims"
+
+	authorizationHeader = "Authorization"
+	refreshHeader       = "refresh"
+	bearerPrefix        = "Bearer "
+)
+
+// Auth 校验访问令牌，并将声明写入 gin.Context 与请求上下文。
+// 开启自动刷新时：
+//   - 访问令牌已过期且请求头携带刷新令牌，换取新令牌后以 401 返回，客户端使用新令牌重试；
+//   - 访问令牌即将过期，在响应头中下发新令牌，请求照常处理。
+func Auth() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := extractToken(ctx)
+		if token == "" {
+			resp.Unauthorized(ctx, consts.ErrUnauthorized)
+			return
+		}
+
+		j := jwt.Default()
+		refresh := j.Config().Refresh
+
+		claims, err := j.ParseAccessToken(token)
+		if err != nil {
+			if errors.Is(err, consts.ErrTokenExpired) && refresh.AutoRefresh {
+				if refreshToken := ctx.GetHeader(refreshHeader); refreshToken != "" {
+					pair, rerr := j.Refresh(ctx.Request.Context(), refreshToken)
+					if rerr == nil {
+						resp.RefreshToken(ctx, pair.AccessToken, pair.RefreshToken, pair.AccessExpiresAt)
+						ctx.Abort()
+						return
+					}
+					err = tokenError(ctx.Request.Context(), rerr)
+				}
+			}
+			resp.Unauthorized(ctx, err)
+			return
+		}
+
+		// 已注销的令牌立即失效
+		revoked, err := j.IsRevoked(ctx.Request.Context(), claims)
+		if err != nil {
+			logger.ErrorContext(ctx.Request.Context(), "查询令牌黑名单失败", zap.String("jti", claims.ID), zap.Error(err))
+			resp.Res(ctx, consts.ErrServiceBusy)
+			ctx.Abort()
+			return
+		}
+		if revoked {
+			resp.Unauthorized(ctx, consts.ErrTokenRevoked)
+			return
+		}
+
+		// 临近过期时自动续签
+		if refresh.AutoRefresh && claims.ExpiresAt != nil && time.Until(claims.ExpiresAt.Time) < refresh.BeforeExpiry {
+			pair, err := j.Renew(ctx.Request.Context(), claims)
+			if err != nil {
+				logger.WarnContext(ctx.Request.Context(), "自动续签令牌失败", zap.Int64("uid", claims.UserID), zap.Error(err))
+			} else if pair != nil {
+				resp.SetToken(ctx, pair.AccessToken, pair.RefreshToken, pair.AccessExpiresAt)
+			}
+		}
+
+		setClaims(ctx, claims)
+		ctx.Next()
+	}
+}
+
+// GetClaims 获取当前请求的令牌声明
+func GetClaims(ctx *gin.Context) (*jwt.Claims, bool) {
+	v, ok := ctx.Get(ClaimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := v.(*jwt.Claims)
+	return claims, ok
+}
+
+// 写入令牌声明
+func setClaims(ctx *gin.Context, claims *jwt.Claims) {
+	ctx.Set(ClaimsKey, claims)
+	c := jwt.NewContext(ctx.Request.Context(), claims)
+	ctx.Request = ctx.Request.WithContext(logger.WithUserID(c, claims.UserID))
+}
+
+// 从请求头中提取 Bearer 令牌
+func extractToken(ctx *gin.Context) string {
+	header := ctx.GetHeader(authorizationHeader)
+	if len(header) > len(bearerPrefix) && strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+		return strings.TrimSpace(header[len(bearerPrefix):])
+	}
+	return ""
+}
+
+// 刷新失败时，令牌类错误原样返回，其余错误记录日志后统一视为令牌无效
+func tokenError(ctx context.Context, err error) error {
+	if jwt.IsTokenError(err) {
+		return err
+	}
+	logger.ErrorContext(ctx, "刷新令牌失败", zap.Error(err))
+	return consts.ErrInvalidToken
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"simple/pkg/consts"
+	"simple/pkg/resp"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+/*
+   @NAME    : metrics
+   @author  : 清风
+   @desc    : HTTP 请求指标中间件
+   @time    : 2025/3/27 21:10
+*/
+
+const meterName = "simple/internal/middleware"
+
+// Metrics 记录请求数、请求耗时与处理中的请求数
+func Metrics() gin.HandlerFunc {
+	meter := otel.Meter(meterName)
+
+	requests, err := meter.Int64Counter("http.server.request.count",
+		metric.WithDescription("请求数"), metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+	duration, err := meter.Float64Histogram("http.server.request.duration",
+		metric.WithDescription("请求耗时"), metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.075, 0.1, 0.25, 0.5, 0.75, 1, 2.5, 5, 7.5, 10))
+	if err != nil {
+		otel.Handle(err)
+	}
+	active, err := meter.Int64UpDownCounter("http.server.active_requests",
+		metric.WithDescription("处理中的请求数"), metric.WithUnit("{request}"))
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return func(ctx *gin.Context) {
+		c := ctx.Request.Context()
+		method := semconv.HTTPRequestMethodKey.String(ctx.Request.Method)
+
+		active.Add(c, 1, metric.WithAttributes(method))
+		// 处理中发生 panic 时也要减回去，避免处理中的请求数只增不减
+		defer active.Add(c, -1, metric.WithAttributes(method))
+		start := time.Now()
+
+		ctx.Next()
+
+		// 未匹配到路由时路由为空，避免按路径记录导致基数过高
+		attrs := metric.WithAttributeSet(attribute.NewSet(
+			method,
+			semconv.HTTPRoute(ctx.FullPath()),
+			semconv.HTTPResponseStatusCode(ctx.Writer.Status()),
+		))
+		requests.Add(c, 1, attrs)
+		duration.Record(c, time.Since(start).Seconds(), attrs)
+	}
+}
+
+// MetricsAuth 校验 Prometheus 拉取请求携带的 Bearer 令牌，令牌为空时不校验
+func MetricsAuth(token string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if token == "" {
+			ctx.Next()
+			return
+		}
+		got, ok := strings.CutPrefix(ctx.GetHeader(authorizationHeader), bearerPrefix)
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			resp.Unauthorized(ctx, consts.ErrUnauthorized)
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"simple/internal/logic/permission"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : permission
+   @author  : 清风
+   @desc    : 权限标识校验中间件
+   @time    : 2025/3/21 21:10
+*/
+
+// RequirePerm 要求当前用户同时拥有全部权限标识，超级管理员直接放行。
+// 必须挂载在 Auth 之后。
+func RequirePerm(codes ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		claims, ok := GetClaims(ctx)
+		if !ok {
+			resp.Unauthorized(ctx, consts.ErrUnauthorized)
+			return
+		}
+
+		allowed, err := permission.Permission().HasPermission(ctx.Request.Context(), claims.UserID, codes...)
+		if err != nil {
+			resp.Res(ctx, err)
+			ctx.Abort()
+			return
+		}
+		if !allowed {
+			logger.DebugContext(ctx.Request.Context(), "权限不足", zap.Int64("uid", claims.UserID), zap.Strings("codes", codes), zap.String("path", ctx.FullPath()))
+			resp.Forbidden(ctx, consts.ErrForbidden)
+			return
+		}
+
+		ctx.Next()
+	}
+}
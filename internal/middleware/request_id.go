@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"simple/pkg/logger"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+/*
+   @NAME    : request_id
+   @author  : 清风
+   @desc    : 请求ID中间件
+   @time    : 2025/3/29 20:20
+*/
+
+// RequestIDHeader 请求ID的请求头与响应头
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID 沿用请求头中的请求ID，没有时生成一个，写入响应头并附加到日志上下文
+func RequestID() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 64 {
+			id = uuid.NewString()
+		}
+
+		ctx.Header(RequestIDHeader, id)
+		ctx.Request = ctx.Request.WithContext(logger.WithRequestID(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
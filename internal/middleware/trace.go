@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"simple/pkg/resp"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+/*
+   @NAME    : trace
+   @author  : 清风
+   @desc    : HTTP 链路追踪中间件
+   @time    : 2025/3/26 21:05
+*/
+
+const tracerName = "simple/internal/middleware"
+
+// Trace 从请求头提取 W3C traceparent 与 baggage 并开启服务端 span。
+// span 写入请求上下文，后续的数据库操作成为其子 span；
+// 追踪ID写入 gin.Context，由 resp 填充到响应的 tid 字段。
+func Trace() gin.HandlerFunc {
+	tracer := otel.Tracer(tracerName)
+
+	return func(ctx *gin.Context) {
+		parent := otel.GetTextMapPropagator().Extract(ctx.Request.Context(), propagation.HeaderCarrier(ctx.Request.Header))
+
+		// 未匹配到路由时只使用请求方法，避免 span 名称基数过高
+		route := ctx.FullPath()
+		name := ctx.Request.Method
+		if route != "" {
+			name += " " + route
+		}
+
+		c, span := tracer.Start(parent, name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(ctx.Request.Method),
+				semconv.HTTPRoute(route),
+				semconv.URLPath(ctx.Request.URL.Path),
+				semconv.ServerAddress(ctx.Request.Host),
+				semconv.ClientAddress(ctx.ClientIP()),
+				semconv.UserAgentOriginal(ctx.Request.UserAgent()),
+			),
+		)
+		defer span.End()
+
+		ctx.Request = ctx.Request.WithContext(c)
+		if sc := span.SpanContext(); sc.HasTraceID() {
+			ctx.Set(resp.TIDKey, sc.TraceID().String())
+		}
+
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		if len(ctx.Errors) > 0 {
+			span.RecordError(fmt.Errorf("%s", ctx.Errors.String()))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package router
+
+import (
+	"simple/internal/global"
+	"simple/internal/handler/auth"
+	"simple/internal/handler/department"
+	"simple/internal/handler/loglevel"
+	"simple/internal/handler/menu"
+	"simple/internal/handler/position"
+	"simple/internal/handler/role"
+	"simple/internal/handler/user"
+	"simple/internal/middleware"
+	"simple/pkg/resp"
+	"simple/pkg/telemetry"
+
+	"github.com/gin-gonic/gin"
+)
+
+/*
+   @NAME    : router
+   @author  : 清风
+   @desc    : 路由注册
+   @time    : 2025/3/10 22:01
+*/
+
+// Setup 注册所有路由
+func Setup(engine *gin.Engine) {
+	engine.HandleMethodNotAllowed = true
+	engine.Use(middleware.Trace(), middleware.RequestID(), middleware.Metrics())
+
+	// prometheus 拉取指标，配置令牌后需携带 Bearer 令牌访问
+	if h := telemetry.MetricsHandler(); h != nil {
+		metrics := &global.Cfg.Telemetry.Metrics
+		engine.GET(telemetry.MetricsPath(metrics), middleware.MetricsAuth(metrics.Token), gin.WrapH(h))
+	}
+	engine.NoRoute(resp.NotFound)
+	engine.NoMethod(resp.NotFound)
+
+	api := engine.Group("/api")
+
+	// 无需登录的接口
+	{
+		auth.RegisterPublic(api)
+	}
+
+	// 需要登录的接口
+	authorized := api.Group("", middleware.Auth())
+	{
+		auth.Register(authorized)
+		role.Register(authorized)
+		user.Register(authorized)
+		department.Register(authorized)
+		position.Register(authorized)
+		menu.Register(authorized)
+		loglevel.Register(authorized)
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+/*
+   @NAME    : auth
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/12 21:30
+*/
+
+// LoginReq 登录请求
+type LoginReq struct {
+	Username string `json:"username" binding:"required"`              // 用户名
+	Password string `json:"password" binding:"required" log:"redact"` // 密码
+	ClientIP string `json:"-"`                                        // 客户端IP，由接口层填充
+}
+
+// LogoutReq 退出登录请求
+type LogoutReq struct {
+	RefreshToken string `json:"refresh_token" log:"redact"` // 刷新令牌，传入时一并注销
+}
+
+// RefreshTokenReq 刷新令牌请求
+type RefreshTokenReq struct {
+	RefreshToken string `json:"refresh_token" binding:"required" log:"redact"` // 刷新令牌
+}
+
+// UnlockReq 解除登录锁定请求，账号与IP至少传入一个
+type UnlockReq struct {
+	Username string `json:"username" binding:"required_without=IP"` // 用户名
+	IP       string `json:"ip" binding:"required_without=Username"` // 客户端IP
+}
@@ -0,0 +1,45 @@
+package department
+
+/*
+   @NAME    : department
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/17 20:10
+*/
+
+// CreateDepartmentReq 创建部门请求
+type CreateDepartmentReq struct {
+	ParentID *int64  `json:"parent_id"`                                         // 上级部门ID，为空时为顶级部门
+	Name     string  `json:"name" binding:"required,max=50"`                    // 部门名称
+	Code     string  `json:"code" binding:"required,max=50"`                    // 部门编码
+	Leader   *string `json:"leader" binding:"omitempty,max=32"`                 // 部门负责人
+	Phone    *string `json:"phone" binding:"omitempty,max=11" log:"mask"`       // 联系电话
+	Email    *string `json:"email" binding:"omitempty,email,max=64" log:"mask"` // 邮箱
+	Sort     int64   `json:"sort" binding:"min=0"`                              // 排序
+	Status   *int64  `json:"status" binding:"omitempty,oneof=1 2"`              // 状态 1:启用 2:禁用
+	Remark   *string `json:"remark" binding:"omitempty,max=255"`                // 备注
+}
+
+// UpdateDepartmentReq 更新部门请求
+type UpdateDepartmentReq struct {
+	ID       int64   `json:"id" binding:"required"`                             // 部门ID
+	ParentID *int64  `json:"parent_id"`                                         // 上级部门ID，为空时为顶级部门
+	Name     string  `json:"name" binding:"required,max=50"`                    // 部门名称
+	Code     string  `json:"code" binding:"required,max=50"`                    // 部门编码
+	Leader   *string `json:"leader" binding:"omitempty,max=32"`                 // 部门负责人
+	Phone    *string `json:"phone" binding:"omitempty,max=11" log:"mask"`       // 联系电话
+	Email    *string `json:"email" binding:"omitempty,email,max=64" log:"mask"` // 邮箱
+	Sort     int64   `json:"sort" binding:"min=0"`                              // 排序
+	Status   *int64  `json:"status" binding:"omitempty,oneof=1 2"`              // 状态 1:启用 2:禁用
+	Remark   *string `json:"remark" binding:"omitempty,max=255"`                // 备注
+}
+
+// DeleteDepartmentReq 删除部门请求
+type DeleteDepartmentReq struct {
+	Ids []int64 `json:"ids" binding:"required,min=1"` // 部门ID列表
+}
+
+// GetDepartmentReq 获取部门请求
+type GetDepartmentReq struct {
+	ID int64 `json:"id" binding:"required"` // 部门ID
+}
@@ -0,0 +1,20 @@
+package loglevel
+
+/*
+   @NAME    : loglevel
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/30 20:10
+*/
+
+// SetLogLevelReq 修改日志级别请求
+type SetLogLevelReq struct {
+	Level string `json:"level" binding:"required,oneof=debug info warn error dpanic panic fatal"` // 日志级别
+	Name  string `json:"name"`                                                                    // 日志名称，为空时修改全局级别
+	TTL   int64  `json:"ttl" binding:"min=0"`                                                     // 有效期(秒)，到期后自动恢复，0 表示不自动恢复
+}
+
+// ResetLogLevelReq 恢复日志级别请求
+type ResetLogLevelReq struct {
+	Name string `form:"name"` // 日志名称，为空时恢复全局级别为配置文件中的级别
+}
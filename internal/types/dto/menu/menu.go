@@ -0,0 +1,89 @@
+package menu
+
+/*
+   @NAME    : menu
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/19 20:30
+*/
+
+// MenuReq 菜单公共字段，Level 由上级菜单自动计算
+type MenuReq struct {
+	ParentID           *int64  `json:"parent_id"`                                           // 上级菜单ID，为空时为顶级菜单
+	Title              string  `json:"title" binding:"required,max=50"`                     // 菜单标题
+	Name               string  `json:"name" binding:"required,max=50"`                      // 菜单名称
+	Path               *string `json:"path" binding:"omitempty,max=128"`                    // 菜单路径
+	Component          *string `json:"component" binding:"omitempty,max=128"`               // 组件路径
+	Redirect           *string `json:"redirect" binding:"omitempty,max=128"`                // 重定向
+	Icon               *string `json:"icon" binding:"omitempty,max=50"`                     // 图标
+	Type               int64   `json:"type" binding:"oneof=0 1 2"`                          // 菜单类型 0:目录 1:菜单 2:按钮
+	Permission         *string `json:"permission" binding:"omitempty,max=128"`              // 权限标识
+	Sort               int64   `json:"sort" binding:"min=0"`                                // 排序
+	IsHidden           *int64  `json:"is_hidden" binding:"omitempty,oneof=1 2"`             // 是否隐藏 1:隐藏 2:显示
+	IsCache            *int64  `json:"is_cache" binding:"omitempty,oneof=1 2"`              // 是否缓存 1:缓存 2:不缓存
+	IsAffix            *int64  `json:"is_affix" binding:"omitempty,oneof=1 2"`              // 是否固定 1:固定 2:不固定
+	Trans              *string `json:"trans" binding:"omitempty,max=100"`                   // 多语言翻译
+	HideBreadcrumb     *int64  `json:"hide_breadcrumb" binding:"omitempty,oneof=1 2"`       // 是否隐藏面包屑 1:隐藏 2:显示
+	HideTab            *int64  `json:"hide_tab" binding:"omitempty,oneof=1 2"`              // 是否隐藏标签页 1:隐藏 2:显示
+	FrameSrc           *string `json:"frame_src" binding:"omitempty,max=255"`               // 内嵌iframe地址
+	CarryParam         *int64  `json:"carry_param" binding:"omitempty,oneof=1 2"`           // 是否携带参数 1:是 2:否
+	HideChildrenInMenu *int64  `json:"hide_children_in_menu" binding:"omitempty,oneof=1 2"` // 是否在菜单中隐藏子节点 1:是 2:否
+	DynamicLevel       *int64  `json:"dynamic_level" binding:"omitempty,min=0"`             // 动态路由层级
+	RealPath           *string `json:"real_path" binding:"omitempty,max=255"`               // 真实路径
+	Status             *int64  `json:"status" binding:"omitempty,oneof=1 2"`                // 状态 1:启用 2:禁用
+}
+
+// CreateMenuReq 创建菜单请求
+type CreateMenuReq struct {
+	MenuReq
+}
+
+// UpdateMenuReq 更新菜单请求
+type UpdateMenuReq struct {
+	ID int64 `json:"id" binding:"required"` // 菜单ID
+	MenuReq
+}
+
+// DeleteMenuReq 删除菜单请求
+type DeleteMenuReq struct {
+	Ids []int64 `json:"ids" binding:"required,min=1"` // 菜单ID列表
+}
+
+// GetMenuReq 获取菜单请求
+type GetMenuReq struct {
+	ID int64 `json:"id" binding:"required"` // 菜单ID
+}
+
+// RouteMeta 前端路由元信息，字段名与 vben-admin 的 RouteMeta 保持一致
+type RouteMeta struct {
+	Title              string `json:"title"`                        // 标题，设置了多语言翻译时为翻译键
+	Icon               string `json:"icon,omitempty"`               // 图标
+	OrderNo            int64  `json:"orderNo"`                      // 排序
+	HideMenu           bool   `json:"hideMenu,omitempty"`           // 是否在菜单中隐藏
+	HideTab            bool   `json:"hideTab,omitempty"`            // 是否隐藏标签页
+	HideBreadcrumb     bool   `json:"hideBreadcrumb,omitempty"`     // 是否隐藏面包屑
+	HideChildrenInMenu bool   `json:"hideChildrenInMenu,omitempty"` // 是否在菜单中隐藏子节点
+	IgnoreKeepAlive    bool   `json:"ignoreKeepAlive,omitempty"`    // 是否不缓存页面
+	Affix              bool   `json:"affix,omitempty"`              // 是否固定标签页
+	CarryParam         bool   `json:"carryParam,omitempty"`         // 标签页是否携带参数
+	FrameSrc           string `json:"frameSrc,omitempty"`           // 内嵌iframe地址
+	DynamicLevel       int64  `json:"dynamicLevel,omitempty"`       // 动态路由可打开的最大标签数
+	RealPath           string `json:"realPath,omitempty"`           // 动态路由的真实路径
+}
+
+// RouteResp 前端路由
+type RouteResp struct {
+	Name      string       `json:"name"`               // 路由名称
+	Path      string       `json:"path"`               // 路由路径
+	Component string       `json:"component"`          // 组件路径
+	Redirect  string       `json:"redirect,omitempty"` // 重定向
+	Meta      RouteMeta    `json:"meta"`               // 元信息
+	Children  []*RouteResp `json:"children,omitempty"` // 子路由
+}
+
+// UserRoutesResp 当前用户的前端路由与按钮权限
+type UserRoutesResp struct {
+	Routes      []*RouteResp `json:"routes"`      // 路由树
+	Permissions []string     `json:"permissions"` // 按钮权限标识
+	HomePath    string       `json:"home_path"`   // 登录后的首页
+}
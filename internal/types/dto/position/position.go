@@ -0,0 +1,60 @@
+package position
+
+/*
+   @NAME    : position
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/18 20:30
+*/
+
+// CreatePositionReq 创建岗位请求
+type CreatePositionReq struct {
+	DepartmentID *int64  `json:"department_id"`                        // 所属部门ID
+	Name         string  `json:"name" binding:"required,max=64"`       // 岗位名称
+	Code         string  `json:"code" binding:"required,max=64"`       // 岗位编码
+	Sort         int64   `json:"sort" binding:"min=0"`                 // 排序
+	Status       *int64  `json:"status" binding:"omitempty,oneof=1 2"` // 状态 1:启用 2:禁用
+	Remark       *string `json:"remark" binding:"omitempty,max=255"`   // 备注
+}
+
+// UpdatePositionReq 更新岗位请求
+type UpdatePositionReq struct {
+	ID           int64   `json:"id" binding:"required"`                // 岗位ID
+	DepartmentID *int64  `json:"department_id"`                        // 所属部门ID，为空时不属于任何部门
+	Name         string  `json:"name" binding:"required,max=64"`       // 岗位名称
+	Code         string  `json:"code" binding:"required,max=64"`       // 岗位编码
+	Sort         int64   `json:"sort" binding:"min=0"`                 // 排序
+	Status       *int64  `json:"status" binding:"omitempty,oneof=1 2"` // 状态 1:启用 2:禁用
+	Remark       *string `json:"remark" binding:"omitempty,max=255"`   // 备注
+}
+
+// DeletePositionReq 删除岗位请求
+type DeletePositionReq struct {
+	Ids []int64 `json:"ids" binding:"required,min=1"` // 岗位ID列表
+}
+
+// GetPositionReq 获取岗位请求
+type GetPositionReq struct {
+	ID int64 `json:"id" binding:"required"` // 岗位ID
+}
+
+// ListPositionReq 岗位列表请求
+type ListPositionReq struct {
+	DepartmentID *int64  `json:"department_id"`                          // 部门ID，包含下级部门的岗位
+	Name         *string `json:"name"`                                   // 岗位名称
+	Code         *string `json:"code"`                                   // 岗位编码
+	Status       *int64  `json:"status"`                                 // 状态
+	Page         int     `json:"page" binding:"required,min=1"`          // 页码
+	Size         int     `json:"size" binding:"required,min=10,max=100"` // 每页数量
+}
+
+// ListPositionItemReq 岗位选项请求
+type ListPositionItemReq struct {
+	DepartmentID *int64 `form:"department_id"` // 部门ID，包含下级部门的岗位
+}
+
+// ListPositionItemResp 岗位选项响应
+type ListPositionItemResp struct {
+	ID   int64  `json:"id"`   // 岗位ID
+	Name string `json:"name"` // 岗位名称
+}
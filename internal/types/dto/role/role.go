@@ -9,23 +9,27 @@ package role
 
 // CreateRoleReq 创建角色请求
 type CreateRoleReq struct {
-	Name          string  `json:"name" binding:"required"`       // 角色名称
-	Code          string  `json:"code" binding:"required"`       // 角色编码
-	DefaultRouter *string `json:"default_router"`                // 默认路由
-	Status        *int64  `json:"status"`                        // 状态 1:启用 2:禁用
-	Remark        *string `json:"remark"`                        // 备注
-	Sort          int64   `json:"sort" binding:"required,min=0"` // 排序
+	Name          string  `json:"name" binding:"required"`                        // 角色名称
+	Code          string  `json:"code" binding:"required"`                        // 角色编码
+	DefaultRouter *string `json:"default_router"`                                 // 默认路由
+	Status        *int64  `json:"status"`                                         // 状态 1:启用 2:禁用
+	Remark        *string `json:"remark"`                                         // 备注
+	Sort          int64   `json:"sort" binding:"required,min=0"`                  // 排序
+	DataScope     *int64  `json:"data_scope" binding:"omitempty,oneof=1 2 3 4 5"` // 数据范围 1:全部 2:本部门 3:本部门及以下 4:仅本人 5:自定义部门
+	DepartmentIds []int64 `json:"department_ids"`                                 // 自定义数据范围的部门ID列表
 }
 
 // UpdateRoleReq 更新角色请求
 type UpdateRoleReq struct {
-	ID            int64   `json:"id" binding:"required"`         // 角色ID
-	Name          string  `json:"name" binding:"required"`       // 角色名称
-	Code          string  `json:"code" binding:"required"`       // 角色编码
-	DefaultRouter *string `json:"default_router"`                // 默认路由
-	Status        *int64  `json:"status"`                        // 状态 1:启用 2:禁用
-	Remark        *string `json:"remark"`                        // 备注
-	Sort          int64   `json:"sort" binding:"required,min=0"` // 排序
+	ID            int64   `json:"id" binding:"required"`                          // 角色ID
+	Name          string  `json:"name" binding:"required"`                        // 角色名称
+	Code          string  `json:"code" binding:"required"`                        // 角色编码
+	DefaultRouter *string `json:"default_router"`                                 // 默认路由
+	Status        *int64  `json:"status"`                                         // 状态 1:启用 2:禁用
+	Remark        *string `json:"remark"`                                         // 备注
+	Sort          int64   `json:"sort" binding:"required,min=0"`                  // 排序
+	DataScope     *int64  `json:"data_scope" binding:"omitempty,oneof=1 2 3 4 5"` // 数据范围 1:全部 2:本部门 3:本部门及以下 4:仅本人 5:自定义部门
+	DepartmentIds []int64 `json:"department_ids"`                                 // 自定义数据范围的部门ID列表
 }
 
 // DeleteRoleReq 删除角色请求
@@ -47,6 +51,12 @@ type ListRoleReq struct {
 	Size   int     `json:"size" binding:"required,min=10,max=100"` // 每页数量
 }
 
+// AssignMenusReq 分配菜单请求，覆盖角色原有菜单
+type AssignMenusReq struct {
+	RoleID  int64   `json:"role_id" binding:"required"` // 角色ID
+	MenuIds []int64 `json:"menu_ids"`                   // 菜单ID列表，为空时清空菜单
+}
+
 // ListRoleItemResp 角色选项响应
 type ListRoleItemResp struct {
 	ID   int64  `json:"id"`   // 角色ID
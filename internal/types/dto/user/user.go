@@ -0,0 +1,69 @@
+package user
+
+/*
+   @NAME    : user
+   @author  : 清风
+   @desc    :
+   @time    : 2025/3/16 14:10
+*/
+
+// CreateUserReq 创建用户请求
+type CreateUserReq struct {
+	Username     string  `json:"username" binding:"required,max=32"`                    // 用户名
+	Password     string  `json:"password" binding:"required,min=6,max=64" log:"redact"` // 密码
+	Name         string  `json:"name" binding:"required,max=32"`                        // 姓名
+	Nickname     *string `json:"nickname" binding:"omitempty,max=64"`                   // 昵称
+	Email        *string `json:"email" binding:"omitempty,email,max=64" log:"mask"`     // 邮箱
+	Mobile       *string `json:"mobile" binding:"omitempty,len=11,numeric" log:"mask"`  // 手机号
+	Avatar       *string `json:"avatar" binding:"omitempty,max=255"`                    // 头像
+	Status       *int64  `json:"status" binding:"omitempty,oneof=1 2"`                  // 状态 1:启用 2:禁用
+	Remark       *string `json:"remark" binding:"omitempty,max=255"`                    // 备注
+	HomePath     *string `json:"home_path" binding:"omitempty,max=128"`                 // 首页路径
+	DepartmentID *int64  `json:"department_id"`                                         // 部门ID
+	PositionID   *int64  `json:"position_id"`                                           // 岗位ID
+	RoleIds      []int64 `json:"role_ids"`                                              // 角色ID列表
+}
+
+// UpdateUserReq 更新用户请求，密码为空时不修改
+type UpdateUserReq struct {
+	ID           int64   `json:"id" binding:"required"`                                  // 用户ID
+	Password     *string `json:"password" binding:"omitempty,min=6,max=64" log:"redact"` // 密码
+	Name         string  `json:"name" binding:"required,max=32"`                         // 姓名
+	Nickname     *string `json:"nickname" binding:"omitempty,max=64"`                    // 昵称
+	Email        *string `json:"email" binding:"omitempty,email,max=64" log:"mask"`      // 邮箱
+	Mobile       *string `json:"mobile" binding:"omitempty,len=11,numeric" log:"mask"`   // 手机号
+	Avatar       *string `json:"avatar" binding:"omitempty,max=255"`                     // 头像
+	Status       *int64  `json:"status" binding:"omitempty,oneof=1 2"`                   // 状态 1:启用 2:禁用
+	Remark       *string `json:"remark" binding:"omitempty,max=255"`                     // 备注
+	HomePath     *string `json:"home_path" binding:"omitempty,max=128"`                  // 首页路径
+	DepartmentID *int64  `json:"department_id"`                                          // 部门ID
+	PositionID   *int64  `json:"position_id"`                                            // 岗位ID
+	RoleIds      []int64 `json:"role_ids"`                                               // 角色ID列表，为nil时不修改
+}
+
+// DeleteUserReq 删除用户请求
+type DeleteUserReq struct {
+	Ids []int64 `json:"ids" binding:"required,min=1"` // 用户ID列表
+}
+
+// GetUserReq 获取用户请求
+type GetUserReq struct {
+	ID int64 `json:"id" binding:"required"` // 用户ID
+}
+
+// ListUserReq 用户列表请求
+type ListUserReq struct {
+	DepartmentID *int64  `json:"department_id"`                          // 部门ID
+	PositionID   *int64  `json:"position_id"`                            // 岗位ID
+	Status       *int64  `json:"status"`                                 // 状态
+	Name         *string `json:"name"`                                   // 姓名
+	Mobile       *string `json:"mobile" log:"mask"`                      // 手机号
+	Page         int     `json:"page" binding:"required,min=1"`          // 页码
+	Size         int     `json:"size" binding:"required,min=10,max=100"` // 每页数量
+}
+
+// AssignRolesReq 分配角色请求，覆盖用户原有角色
+type AssignRolesReq struct {
+	UserID  int64   `json:"user_id" binding:"required"` // 用户ID
+	RoleIds []int64 `json:"role_ids"`                   // 角色ID列表，为空时清空角色
+}
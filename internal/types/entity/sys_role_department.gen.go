@@ -0,0 +1,25 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package entity
+
+import (
+	"time"
+)
+
+const TableNameRoleDepartment = "sys_role_department"
+
+// RoleDepartment 角色-数据权限部门关系表
+type RoleDepartment struct {
+	ID           int64      `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:主键ID|Primary key" json:"id"`                // 主键ID|Primary key
+	RoleID       int64      `gorm:"column:role_id;type:bigint unsigned;not null;comment:角色ID|Role ID" json:"role_id"`                               // 角色ID|Role ID
+	DepartmentID int64      `gorm:"column:department_id;type:bigint unsigned;not null;comment:部门ID|Department ID" json:"department_id"`             // 部门ID|Department ID
+	CreatedAt    *time.Time `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间|Created Time" json:"created_at"` // 创建时间|Created Time
+	UpdatedAt    *time.Time `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:更新时间|Updated Time" json:"updated_at"` // 更新时间|Updated Time
+}
+
+// TableName RoleDepartment's table name
+func (*RoleDepartment) TableName() string {
+	return TableNameRoleDepartment
+}
@@ -0,0 +1,25 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package entity
+
+import (
+	"time"
+)
+
+const TableNameRoleMenu = "sys_role_menu"
+
+// RoleMenu 角色-菜单关系表
+type RoleMenu struct {
+	ID        int64      `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:主键ID|Primary key" json:"id"`                // 主键ID|Primary key
+	RoleID    int64      `gorm:"column:role_id;type:bigint unsigned;not null;comment:角色ID|Role ID" json:"role_id"`                               // 角色ID|Role ID
+	MenuID    int64      `gorm:"column:menu_id;type:bigint unsigned;not null;comment:菜单ID|Menu ID" json:"menu_id"`                               // 菜单ID|Menu ID
+	CreatedAt *time.Time `gorm:"column:created_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:创建时间|Created Time" json:"created_at"` // 创建时间|Created Time
+	UpdatedAt *time.Time `gorm:"column:updated_at;type:datetime;not null;default:CURRENT_TIMESTAMP;comment:更新时间|Updated Time" json:"updated_at"` // 更新时间|Updated Time
+}
+
+// TableName RoleMenu's table name
+func (*RoleMenu) TableName() string {
+	return TableNameRoleMenu
+}
@@ -17,8 +17,8 @@ type User struct {
 	ID           int64          `gorm:"column:id;type:bigint unsigned;primaryKey;autoIncrement:true;comment:主键ID|Primary key" json:"id"`                     // 主键ID|Primary key
 	UUID         string         `gorm:"column:uuid;type:char(36);not null;comment:唯一标识符|UUID" json:"uuid"`                                                   // 唯一标识符|UUID
 	Username     string         `gorm:"column:username;type:varchar(32);not null;comment:用户名|Username" json:"username"`                                      // 用户名|Username
-	Password     string         `gorm:"column:password;type:varchar(32);not null;comment:密码|Password" json:"password"`                                       // 密码|Password
-	Salt         string         `gorm:"column:salt;type:varchar(10);not null;comment:盐值|Salt" json:"salt"`                                                   // 盐值|Salt
+	Password     string         `gorm:"column:password;type:varchar(255);not null;comment:密码哈希|Password hash" json:"-"`                                      // 密码哈希|Password hash
+	Salt         string         `gorm:"column:salt;type:varchar(10);not null;comment:旧MD5密码的盐值|Legacy MD5 salt" json:"-"`                                    // 旧MD5密码的盐值|Legacy MD5 salt
 	Name         string         `gorm:"column:name;type:varchar(32);not null;comment:姓名|Name" json:"name"`                                                   // 姓名|Name
 	Nickname     *string        `gorm:"column:nickname;type:varchar(64);comment:昵称|Nickname" json:"nickname"`                                                // 昵称|Nickname
 	Email        *string        `gorm:"column:email;type:varchar(64);comment:邮箱|Email" json:"email"`                                                         // 邮箱|Email
@@ -26,7 +26,7 @@ type User struct {
 	Avatar       *string        `gorm:"column:avatar;type:varchar(255);comment:头像|Avatar" json:"avatar"`                                                     // 头像|Avatar
 	Status       *int64         `gorm:"column:status;type:tinyint unsigned;not null;default:1;comment:状态 1:启用 2:禁用|Status 1:Enable 2:Disable" json:"status"` // 状态 1:启用 2:禁用|Status 1:Enable 2:Disable
 	Remark       *string        `gorm:"column:remark;type:varchar(255);comment:备注|Remark" json:"remark"`                                                     // 备注|Remark
-	HomePath     *string        `gorm:"column:home_path;type:varchar(128);not null;default:/dashboard;comment:首页路径|Home Path" json:"home_path"`              // 首页路径|Home Path
+	HomePath     *string        `gorm:"column:home_path;type:varchar(128);comment:首页路径，为空时使用角色的默认路由|Home Path" json:"home_path"`                             // 首页路径，为空时使用角色的默认路由|Home Path
 	DepartmentID *int64         `gorm:"column:department_id;type:bigint unsigned;comment:部门ID|Department ID" json:"department_id"`                           // 部门ID|Department ID
 	PositionID   *int64         `gorm:"column:position_id;type:bigint unsigned;comment:岗位ID|Position ID" json:"position_id"`                                 // 岗位ID|Position ID
 	LastLoginAt  *time.Time     `gorm:"column:last_login_at;type:datetime;comment:最后登录时间|Last Login Time" json:"last_login_at"`                              // 最后登录时间|Last Login Time
@@ -16,13 +16,15 @@ import (
 )
 
 var (
-	Q          = new(Query)
-	Department *department
-	Menu       *menu
-	Position   *position
-	Role       *role
-	User       *user
-	UserRole   *userRole
+	Q              = new(Query)
+	Department     *department
+	Menu           *menu
+	Position       *position
+	Role           *role
+	RoleDepartment *roleDepartment
+	RoleMenu       *roleMenu
+	User           *user
+	UserRole       *userRole
 )
 
 func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
@@ -31,44 +33,52 @@ func SetDefault(db *gorm.DB, opts ...gen.DOOption) {
 	Menu = &Q.Menu
 	Position = &Q.Position
 	Role = &Q.Role
+	RoleDepartment = &Q.RoleDepartment
+	RoleMenu = &Q.RoleMenu
 	User = &Q.User
 	UserRole = &Q.UserRole
 }
 
 func Use(db *gorm.DB, opts ...gen.DOOption) *Query {
 	return &Query{
-		db:         db,
-		Department: newDepartment(db, opts...),
-		Menu:       newMenu(db, opts...),
-		Position:   newPosition(db, opts...),
-		Role:       newRole(db, opts...),
-		User:       newUser(db, opts...),
-		UserRole:   newUserRole(db, opts...),
+		db:             db,
+		Department:     newDepartment(db, opts...),
+		Menu:           newMenu(db, opts...),
+		Position:       newPosition(db, opts...),
+		Role:           newRole(db, opts...),
+		RoleDepartment: newRoleDepartment(db, opts...),
+		RoleMenu:       newRoleMenu(db, opts...),
+		User:           newUser(db, opts...),
+		UserRole:       newUserRole(db, opts...),
 	}
 }
 
 type Query struct {
 	db *gorm.DB
 
-	Department department
-	Menu       menu
-	Position   position
-	Role       role
-	User       user
-	UserRole   userRole
+	Department     department
+	Menu           menu
+	Position       position
+	Role           role
+	RoleDepartment roleDepartment
+	RoleMenu       roleMenu
+	User           user
+	UserRole       userRole
 }
 
 func (q *Query) Available() bool { return q.db != nil }
 
 func (q *Query) clone(db *gorm.DB) *Query {
 	return &Query{
-		db:         db,
-		Department: q.Department.clone(db),
-		Menu:       q.Menu.clone(db),
-		Position:   q.Position.clone(db),
-		Role:       q.Role.clone(db),
-		User:       q.User.clone(db),
-		UserRole:   q.UserRole.clone(db),
+		db:             db,
+		Department:     q.Department.clone(db),
+		Menu:           q.Menu.clone(db),
+		Position:       q.Position.clone(db),
+		Role:           q.Role.clone(db),
+		RoleDepartment: q.RoleDepartment.clone(db),
+		RoleMenu:       q.RoleMenu.clone(db),
+		User:           q.User.clone(db),
+		UserRole:       q.UserRole.clone(db),
 	}
 }
 
@@ -82,33 +92,39 @@ func (q *Query) WriteDB() *Query {
 
 func (q *Query) ReplaceDB(db *gorm.DB) *Query {
 	return &Query{
-		db:         db,
-		Department: q.Department.replaceDB(db),
-		Menu:       q.Menu.replaceDB(db),
-		Position:   q.Position.replaceDB(db),
-		Role:       q.Role.replaceDB(db),
-		User:       q.User.replaceDB(db),
-		UserRole:   q.UserRole.replaceDB(db),
+		db:             db,
+		Department:     q.Department.replaceDB(db),
+		Menu:           q.Menu.replaceDB(db),
+		Position:       q.Position.replaceDB(db),
+		Role:           q.Role.replaceDB(db),
+		RoleDepartment: q.RoleDepartment.replaceDB(db),
+		RoleMenu:       q.RoleMenu.replaceDB(db),
+		User:           q.User.replaceDB(db),
+		UserRole:       q.UserRole.replaceDB(db),
 	}
 }
 
 type queryCtx struct {
-	Department *departmentDo
-	Menu       *menuDo
-	Position   *positionDo
-	Role       *roleDo
-	User       *userDo
-	UserRole   *userRoleDo
+	Department     *departmentDo
+	Menu           *menuDo
+	Position       *positionDo
+	Role           *roleDo
+	RoleDepartment *roleDepartmentDo
+	RoleMenu       *roleMenuDo
+	User           *userDo
+	UserRole       *userRoleDo
 }
 
 func (q *Query) WithContext(ctx context.Context) *queryCtx {
 	return &queryCtx{
-		Department: q.Department.WithContext(ctx),
-		Menu:       q.Menu.WithContext(ctx),
-		Position:   q.Position.WithContext(ctx),
-		Role:       q.Role.WithContext(ctx),
-		User:       q.User.WithContext(ctx),
-		UserRole:   q.UserRole.WithContext(ctx),
+		Department:     q.Department.WithContext(ctx),
+		Menu:           q.Menu.WithContext(ctx),
+		Position:       q.Position.WithContext(ctx),
+		Role:           q.Role.WithContext(ctx),
+		RoleDepartment: q.RoleDepartment.WithContext(ctx),
+		RoleMenu:       q.RoleMenu.WithContext(ctx),
+		User:           q.User.WithContext(ctx),
+		UserRole:       q.UserRole.WithContext(ctx),
 	}
 }
 
@@ -34,6 +34,7 @@ func newRole(db *gorm.DB, opts ...gen.DOOption) role {
 	_role.Status = field.NewInt64(tableName, "status")
 	_role.Remark = field.NewString(tableName, "remark")
 	_role.Sort = field.NewInt64(tableName, "sort")
+	_role.DataScope = field.NewInt64(tableName, "data_scope")
 	_role.CreatedAt = field.NewTime(tableName, "created_at")
 	_role.UpdatedAt = field.NewTime(tableName, "updated_at")
 	_role.DeletedAt = field.NewField(tableName, "deleted_at")
@@ -55,6 +56,7 @@ type role struct {
 	Status        field.Int64  // 状态 1:启用 2:禁用|Status 1:Enable 2:Disable
 	Remark        field.String // 备注|Remark
 	Sort          field.Int64  // 排序|Sort
+	DataScope     field.Int64  // 数据范围 1:全部 2:本部门 3:本部门及以下 4:仅本人 5:自定义部门|Data scope 1:All 2:Department 3:Department and below 4:Self 5:Custom
 	CreatedAt     field.Time   // 创建时间|Created Time
 	UpdatedAt     field.Time   // 更新时间|Updated Time
 	DeletedAt     field.Field  // 删除时间|Deleted Time
@@ -81,6 +83,7 @@ func (r *role) updateTableName(table string) *role {
 	r.Status = field.NewInt64(table, "status")
 	r.Remark = field.NewString(table, "remark")
 	r.Sort = field.NewInt64(table, "sort")
+	r.DataScope = field.NewInt64(table, "data_scope")
 	r.CreatedAt = field.NewTime(table, "created_at")
 	r.UpdatedAt = field.NewTime(table, "updated_at")
 	r.DeletedAt = field.NewField(table, "deleted_at")
@@ -100,7 +103,7 @@ func (r *role) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
 }
 
 func (r *role) fillFieldMap() {
-	r.fieldMap = make(map[string]field.Expr, 11)
+	r.fieldMap = make(map[string]field.Expr, 12)
 	r.fieldMap["id"] = r.ID
 	r.fieldMap["name"] = r.Name
 	r.fieldMap["code"] = r.Code
@@ -108,6 +111,7 @@ func (r *role) fillFieldMap() {
 	r.fieldMap["status"] = r.Status
 	r.fieldMap["remark"] = r.Remark
 	r.fieldMap["sort"] = r.Sort
+	r.fieldMap["data_scope"] = r.DataScope
 	r.fieldMap["created_at"] = r.CreatedAt
 	r.fieldMap["updated_at"] = r.UpdatedAt
 	r.fieldMap["deleted_at"] = r.DeletedAt
@@ -0,0 +1,336 @@
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+// Code generated by gorm.io/gen. DO NOT EDIT.
+
+package query
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"gorm.io/gen"
+	"gorm.io/gen/field"
+
+	"gorm.io/plugin/dbresolver"
+
+	"simple/internal/types/entity"
+)
+
+func newRoleDepartment(db *gorm.DB, opts ...gen.DOOption) roleDepartment {
+	_roleDepartment := roleDepartment{}
+
+	_roleDepartment.roleDepartmentDo.UseDB(db, opts...)
+	_roleDepartment.roleDepartmentDo.UseModel(&entity.RoleDepartment{})
+
+	tableName := _roleDepartment.roleDepartmentDo.TableName()
+	_roleDepartment.ALL = field.NewAsterisk(tableName)
+	_roleDepartment.ID = field.NewInt64(tableName, "id")
+	_roleDepartment.RoleID = field.NewInt64(tableName, "role_id")
+	_roleDepartment.DepartmentID = field.NewInt64(tableName, "department_id")
+	_roleDepartment.CreatedAt = field.NewTime(tableName, "created_at")
+	_roleDepartment.UpdatedAt = field.NewTime(tableName, "updated_at")
+
+	_roleDepartment.fillFieldMap()
+
+	return _roleDepartment
+}
+
+// roleDepartment 角色-数据权限部门关系表
+type roleDepartment struct {
+	roleDepartmentDo
+
+	ALL          field.Asterisk
+	ID           field.Int64 // 主键ID|Primary key
+	RoleID       field.Int64 // 角色ID|Role ID
+	DepartmentID field.Int64 // 部门ID|Department ID
+	CreatedAt    field.Time  // 创建时间|Created Time
+	UpdatedAt    field.Time  // 更新时间|Updated Time
+
+	fieldMap map[string]field.Expr
+}
+
+func (r roleDepartment) Table(newTableName string) *roleDepartment {
+	r.roleDepartmentDo.UseTable(newTableName)
+	return r.updateTableName(newTableName)
+}
+
+func (r roleDepartment) As(alias string) *roleDepartment {
+	r.roleDepartmentDo.DO = *(r.roleDepartmentDo.As(alias).(*gen.DO))
+	return r.updateTableName(alias)
+}
+
+func (r *roleDepartment) updateTableName(table string) *roleDepartment {
+	r.ALL = field.NewAsterisk(table)
+	r.ID = field.NewInt64(table, "id")
+	r.RoleID = field.NewInt64(table, "role_id")
+	r.DepartmentID = field.NewInt64(table, "department_id")
+	r.CreatedAt = field.NewTime(table, "created_at")
+	r.UpdatedAt = field.NewTime(table, "updated_at")
+
+	r.fillFieldMap()
+
+	return r
+}
+
+func (r *roleDepartment) GetFieldByName(fieldName string) (field.OrderExpr, bool) {
+	_f, ok := r.fieldMap[fieldName]
+	if !ok || _f == nil {
+		return nil, false
+	}
+	_oe, ok := _f.(field.OrderExpr)
+	return _oe, ok
+}
+
+func (r *roleDepartment) fillFieldMap() {
+	r.fieldMap = make(map[string]field.Expr, 5)
+	r.fieldMap["id"] = r.ID
+	r.fieldMap["role_id"] = r.RoleID
+	r.fieldMap["department_id"] = r.DepartmentID
+	r.fieldMap["created_at"] = r.CreatedAt
+	r.fieldMap["updated_at"] = r.UpdatedAt
+}
+
+func (r roleDepartment) clone(db *gorm.DB) roleDepartment {
+	r.roleDepartmentDo.ReplaceConnPool(db.Statement.ConnPool)
+	return r
+}
+
+func (r roleDepartment) replaceDB(db *gorm.DB) roleDepartment {
+	r.roleDepartmentDo.ReplaceDB(db)
+	return r
+}
+
+type roleDepartmentDo struct{ gen.DO }
+
+func (r roleDepartmentDo) Debug() *roleDepartmentDo {
+	return r.withDO(r.DO.Debug())
+}
+
+func (r roleDepartmentDo) WithContext(ctx context.Context) *roleDepartmentDo {
+	return r.withDO(r.DO.WithContext(ctx))
+}
+
+func (r roleDepartmentDo) ReadDB() *roleDepartmentDo {
+	return r.Clauses(dbresolver.Read)
+}
+
+func (r roleDepartmentDo) WriteDB() *roleDepartmentDo {
+	return r.Clauses(dbresolver.Write)
+}
+
+func (r roleDepartmentDo) Session(config *gorm.Session) *roleDepartmentDo {
+	return r.withDO(r.DO.Session(config))
+}
+
+func (r roleDepartmentDo) Clauses(conds ...clause.Expression) *roleDepartmentDo {
+	return r.withDO(r.DO.Clauses(conds...))
+}
+
+func (r roleDepartmentDo) Returning(value interface{}, columns ...string) *roleDepartmentDo {
+	return r.withDO(r.DO.Returning(value, columns...))
+}
+
+func (r roleDepartmentDo) Not(conds ...gen.Condition) *roleDepartmentDo {
+	return r.withDO(r.DO.Not(conds...))
+}
+
+func (r roleDepartmentDo) Or(conds ...gen.Condition) *roleDepartmentDo {
+	return r.withDO(r.DO.Or(conds...))
+}
+
+func (r roleDepartmentDo) Select(conds ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.Select(conds...))
+}
+
+func (r roleDepartmentDo) Where(conds ...gen.Condition) *roleDepartmentDo {
+	return r.withDO(r.DO.Where(conds...))
+}
+
+func (r roleDepartmentDo) Order(conds ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.Order(conds...))
+}
+
+func (r roleDepartmentDo) Distinct(cols ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.Distinct(cols...))
+}
+
+func (r roleDepartmentDo) Omit(cols ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.Omit(cols...))
+}
+
+func (r roleDepartmentDo) Join(table schema.Tabler, on ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.Join(table, on...))
+}
+
+func (r roleDepartmentDo) LeftJoin(table schema.Tabler, on ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.LeftJoin(table, on...))
+}
+
+func (r roleDepartmentDo) RightJoin(table schema.Tabler, on ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.RightJoin(table, on...))
+}
+
+func (r roleDepartmentDo) Group(cols ...field.Expr) *roleDepartmentDo {
+	return r.withDO(r.DO.Group(cols...))
+}
+
+func (r roleDepartmentDo) Having(conds ...gen.Condition) *roleDepartmentDo {
+	return r.withDO(r.DO.Having(conds...))
+}
+
+func (r roleDepartmentDo) Limit(limit int) *roleDepartmentDo {
+	return r.withDO(r.DO.Limit(limit))
+}
+
+func (r roleDepartmentDo) Offset(offset int) *roleDepartmentDo {
+	return r.withDO(r.DO.Offset(offset))
+}
+
+func (r roleDepartmentDo) Scopes(funcs ...func(gen.Dao) gen.Dao) *roleDepartmentDo {
+	return r.withDO(r.DO.Scopes(funcs...))
+}
+
+func (r roleDepartmentDo) Unscoped() *roleDepartmentDo {
+	return r.withDO(r.DO.Unscoped())
+}
+
+func (r roleDepartmentDo) Create(values ...*entity.RoleDepartment) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return r.DO.Create(values)
+}
+
+func (r roleDepartmentDo) CreateInBatches(values []*entity.RoleDepartment, batchSize int) error {
+	return r.DO.CreateInBatches(values, batchSize)
+}
+
+// Save : !!! underlying implementation is different with GORM
+// The method is equivalent to executing the statement: db.Clauses(clause.OnConflict{UpdateAll: true}).Create(values)
+func (r roleDepartmentDo) Save(values ...*entity.RoleDepartment) error {
+	if len(values) == 0 {
+		return nil
+	}
+	return r.DO.Save(values)
+}
+
+func (r roleDepartmentDo) First() (*entity.RoleDepartment, error) {
+	if result, err := r.DO.First(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.RoleDepartment), nil
+	}
+}
+
+func (r roleDepartmentDo) Take() (*entity.RoleDepartment, error) {
+	if result, err := r.DO.Take(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.RoleDepartment), nil
+	}
+}
+
+func (r roleDepartmentDo) Last() (*entity.RoleDepartment, error) {
+	if result, err := r.DO.Last(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.RoleDepartment), nil
+	}
+}
+
+func (r roleDepartmentDo) Find() ([]*entity.RoleDepartment, error) {
+	result, err := r.DO.Find()
+	return result.([]*entity.RoleDepartment), err
+}
+
+func (r roleDepartmentDo) FindInBatch(batchSize int, fc func(tx gen.Dao, batch int) error) (results []*entity.RoleDepartment, err error) {
+	buf := make([]*entity.RoleDepartment, 0, batchSize)
+	err = r.DO.FindInBatches(&buf, batchSize, func(tx gen.Dao, batch int) error {
+		defer func() { results = append(results, buf...) }()
+		return fc(tx, batch)
+	})
+	return results, err
+}
+
+func (r roleDepartmentDo) FindInBatches(result *[]*entity.RoleDepartment, batchSize int, fc func(tx gen.Dao, batch int) error) error {
+	return r.DO.FindInBatches(result, batchSize, fc)
+}
+
+func (r roleDepartmentDo) Attrs(attrs ...field.AssignExpr) *roleDepartmentDo {
+	return r.withDO(r.DO.Attrs(attrs...))
+}
+
+func (r roleDepartmentDo) Assign(attrs ...field.AssignExpr) *roleDepartmentDo {
+	return r.withDO(r.DO.Assign(attrs...))
+}
+
+func (r roleDepartmentDo) Joins(fields ...field.RelationField) *roleDepartmentDo {
+	for _, _f := range fields {
+		r = *r.withDO(r.DO.Joins(_f))
+	}
+	return &r
+}
+
+func (r roleDepartmentDo) Preload(fields ...field.RelationField) *roleDepartmentDo {
+	for _, _f := range fields {
+		r = *r.withDO(r.DO.Preload(_f))
+	}
+	return &r
+}
+
+func (r roleDepartmentDo) FirstOrInit() (*entity.RoleDepartment, error) {
+	if result, err := r.DO.FirstOrInit(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.RoleDepartment), nil
+	}
+}
+
+func (r roleDepartmentDo) FirstOrCreate() (*entity.RoleDepartment, error) {
+	if result, err := r.DO.FirstOrCreate(); err != nil {
+		return nil, err
+	} else {
+		return result.(*entity.RoleDepartment), nil
+	}
+}
+
+func (r roleDepartmentDo) FindByPage(offset int, limit int) (result []*entity.RoleDepartment, count int64, err error) {
+	result, err = r.Offset(offset).Limit(limit).Find()
+	if err != nil {
+		return
+	}
+
+	if size := len(result); 0 < limit && 0 < size && size < limit {
+		count = int64(size + offset)
+		return
+	}
+
+	count, err = r.Offset(-1).Limit(-1).Count()
+	return
+}
+
+func (r roleDepartmentDo) ScanByPage(result interface{}, offset int, limit int) (count int64, err error) {
+	count, err = r.Count()
+	if err != nil {
+		return
+	}
+
+	err = r.Offset(offset).Limit(limit).Scan(result)
+	return
+}
+
+func (r roleDepartmentDo) Scan(result interface{}) (err error) {
+	return r.DO.Scan(result)
+}
+
+func (r roleDepartmentDo) Delete(models ...*entity.RoleDepartment) (result gen.ResultInfo, err error) {
+	return r.DO.Delete(models)
+}
+
+func (r *roleDepartmentDo) withDO(do gen.Dao) *roleDepartmentDo {
+	r.DO = *do.(*gen.DO)
+	return r
+}
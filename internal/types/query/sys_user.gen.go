@@ -61,8 +61,8 @@ type user struct {
 	ID           field.Int64  // 主键ID|Primary key
 	UUID         field.String // 唯一标识符|UUID
 	Username     field.String // 用户名|Username
-	Password     field.String // 密码|Password
-	Salt         field.String // 盐值|Salt
+	Password     field.String // 密码哈希|Password hash
+	Salt         field.String // 旧MD5密码的盐值|Legacy MD5 salt
 	Name         field.String // 姓名|Name
 	Nickname     field.String // 昵称|Nickname
 	Email        field.String // 邮箱|Email
@@ -70,7 +70,7 @@ type user struct {
 	Avatar       field.String // 头像|Avatar
 	Status       field.Int64  // 状态 1:启用 2:禁用|Status 1:Enable 2:Disable
 	Remark       field.String // 备注|Remark
-	HomePath     field.String // 首页路径|Home Path
+	HomePath     field.String // 首页路径，为空时使用角色的默认路由|Home Path
 	DepartmentID field.Int64  // 部门ID|Department ID
 	PositionID   field.Int64  // 岗位ID|Position ID
 	LastLoginAt  field.Time   // 最后登录时间|Last Login Time
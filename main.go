@@ -1,14 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"simple/internal/global"
+	"simple/internal/logic/builtin"
+	"simple/internal/router"
 	"simple/internal/types/query"
 	"simple/model"
 	"simple/pkg/cache"
 	"simple/pkg/config"
 	"simple/pkg/database"
+	"simple/pkg/jwt"
 	"simple/pkg/logger"
+	"simple/pkg/password"
+	"simple/pkg/server"
+	"simple/pkg/telemetry"
+	"time"
 
 	"go.uber.org/zap"
 )
@@ -39,7 +47,32 @@ func main() {
 	}
 	defer logger.Sync()
 
-	if global.DB, err = database.Init(&global.Cfg.Database); err != nil {
+	// 配置文件中的日志级别变化时同步修改
+	global.Config.Watch("log.level", func(value interface{}) {
+		if err := logger.SetConfigLevel(fmt.Sprint(value)); err != nil {
+			logger.Error("修改日志级别失败", zap.Any("level", value), zap.Error(err))
+			return
+		}
+		logger.Warn("日志级别已修改", zap.Any("level", value))
+	})
+
+	// 初始化失败时已安装的 Provider 同样需要关闭
+	err = telemetry.Setup(&global.Cfg.Telemetry)
+	defer shutdownTelemetry()
+	if err != nil {
+		logger.Error("遥测初始化失败", zap.Error(err))
+		panic(err)
+	}
+	if global.Cfg.Telemetry.Logs.Enabled {
+		if err = logger.EnableOTel(&global.Cfg.Telemetry.Logs); err != nil {
+			logger.Error("OTel 日志初始化失败", zap.Error(err))
+		}
+	}
+
+	// 初始化失败时已打开的连接池同样需要关闭
+	global.DB, err = database.Init(&global.Cfg.Database)
+	defer closeDatabase()
+	if err != nil {
 		logger.Error("数据类连接失败", zap.Error(err))
 		panic(err)
 	} else {
@@ -47,29 +80,71 @@ func main() {
 		logger.Info("数据类连接成功")
 	}
 
-	if err = cache.Setup(&global.Cfg.Redis); err != nil {
+	err = cache.Setup(&global.Cfg.Redis)
+	defer closeCache()
+	if err != nil {
 		logger.Error("redis 缓存连接失败", zap.Error(err))
 		panic(err)
 	} else {
 		logger.Info("redis 缓存连接成功")
 	}
-	defer Close()
-}
 
-func Close() {
-	if db, err := global.DB.DB(); err != nil {
+	if global.Cfg.Telemetry.Metrics.Enabled {
+		if err = database.RegisterMetrics(); err != nil {
+			logger.Error("数据库指标注册失败", zap.Error(err))
+		}
+		if err = cache.RegisterMetrics(); err != nil {
+			logger.Error("redis 指标注册失败", zap.Error(err))
+		}
+	}
+
+	if err = jwt.Setup(&global.Cfg.JWT, cache.Client()); err != nil {
+		logger.Error("JWT 初始化失败", zap.Error(err))
 		panic(err)
+	}
+	password.Setup(&global.Cfg.Auth.Password)
+	builtin.Setup(&global.Cfg.Auth.Builtin)
+
+	engine, err := server.NewEngine(&global.Cfg.Server)
+	if err != nil {
+		logger.Error("HTTP 服务初始化失败", zap.Error(err))
+		panic(err)
+	}
+	router.Setup(engine)
+	if err = server.New(&global.Cfg.Server, engine).Run(); err != nil {
+		logger.Error("HTTP 服务异常退出", zap.Error(err))
+	}
+}
+
+// 关闭数据库连接
+func closeDatabase() {
+	if err := database.Close(); err != nil {
+		logger.Error("数据库连接关闭失败", zap.Error(err))
 	} else {
-		if err = db.Close(); err != nil {
-			panic(err)
-		} else {
-			logger.Info("数据库连接关闭成功")
-		}
+		logger.Info("数据库连接关闭成功")
 	}
+}
 
+// 关闭 redis 连接
+func closeCache() {
 	if err := cache.Close(); err != nil {
-		panic(err)
+		logger.Error("redis 缓存连接关闭失败", zap.Error(err))
 	} else {
 		logger.Info("redis 缓存连接关闭成功")
 	}
 }
+
+// 等待导出器发送剩余数据
+func shutdownTelemetry() {
+	timeout := global.Cfg.Telemetry.OTLP.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := telemetry.Shutdown(ctx); err != nil {
+		logger.Error("遥测数据刷新失败", zap.Error(err))
+	} else {
+		logger.Info("遥测数据刷新成功")
+	}
+}
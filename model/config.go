@@ -15,17 +15,20 @@ type Config struct {
 	Database  DatabaseConfig  `yaml:"database" mapstructure:"database"`
 	Redis     RedisConfig     `yaml:"redis" mapstructure:"redis"`
 	JWT       JWTConfig       `yaml:"jwt" mapstructure:"jwt"`
+	Auth      AuthConfig      `yaml:"auth" mapstructure:"auth"`
 	Telemetry TelemetryConfig `yaml:"telemetry" mapstructure:"telemetry"`
 	Log       LogConfig       `yaml:"log" mapstructure:"log"`
 }
 
 // ServerConfig 服务器配置
 type ServerConfig struct {
-	Port         int           `yaml:"port" mapstructure:"port"`
-	Mode         string        `yaml:"mode" mapstructure:"mode"`
-	Static       string        `yaml:"static" mapstructure:"static"`
-	ReadTimeout  time.Duration `yaml:"read_timeout" mapstructure:"read_timeout"`
-	WriteTimeout time.Duration `yaml:"write_timeout" mapstructure:"write_timeout"`
+	Port            int           `yaml:"port" mapstructure:"port"`
+	Mode            string        `yaml:"mode" mapstructure:"mode"`
+	Static          string        `yaml:"static" mapstructure:"static"`
+	ReadTimeout     time.Duration `yaml:"read_timeout" mapstructure:"read_timeout"`
+	WriteTimeout    time.Duration `yaml:"write_timeout" mapstructure:"write_timeout"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" mapstructure:"shutdown_timeout"`
+	TrustedProxies  []string      `yaml:"trusted_proxies" mapstructure:"trusted_proxies"`
 }
 
 // DatabaseConfig 数据库配置
@@ -58,10 +61,9 @@ type DBLoggerConfig struct {
 	Level                string  `yaml:"level" mapstructure:"level"`
 	SlowThreshold        float64 `yaml:"slow_threshold" mapstructure:"slow_threshold"`
 	IgnoreRecordNotFound bool    `yaml:"ignore_record_not_found" mapstructure:"ignore_record_not_found"`
-	Colorful             bool    `yaml:"colorful" mapstructure:"colorful"`
-	LogFilePath          string  `yaml:"log_file_path" mapstructure:"log_file_path"`
 	TraceFields          bool    `yaml:"trace_fields" mapstructure:"trace_fields"`
 	ContextFields        bool    `yaml:"context_fields" mapstructure:"context_fields"`
+	LogParams            bool    `yaml:"log_params" mapstructure:"log_params"`
 }
 
 // DBTracingConfig 数据库链路追踪配置
@@ -171,6 +173,53 @@ type JWTRefresh struct {
 	AutoRefresh  bool          `yaml:"auto_refresh" mapstructure:"auto_refresh"`
 	BeforeExpiry time.Duration `yaml:"before_expiry" mapstructure:"before_expiry"`
 	Reuse        bool          `yaml:"reuse" mapstructure:"reuse"`
+	ReuseGrace   time.Duration `yaml:"reuse_grace" mapstructure:"reuse_grace"`
+}
+
+// AuthConfig 登录认证配置
+type AuthConfig struct {
+	Password   PasswordConfig   `yaml:"password" mapstructure:"password"`
+	Lockout    LockoutConfig    `yaml:"lockout" mapstructure:"lockout"`
+	Permission PermissionConfig `yaml:"permission" mapstructure:"permission"`
+	Builtin    BuiltinConfig    `yaml:"builtin" mapstructure:"builtin"`
+}
+
+// PasswordConfig 密码哈希配置
+type PasswordConfig struct {
+	Algorithm  string       `yaml:"algorithm" mapstructure:"algorithm"`
+	BcryptCost int          `yaml:"bcrypt_cost" mapstructure:"bcrypt_cost"`
+	Argon2     Argon2Config `yaml:"argon2" mapstructure:"argon2"`
+}
+
+// Argon2Config argon2id 参数配置
+type Argon2Config struct {
+	Memory      uint32 `yaml:"memory" mapstructure:"memory"`
+	Iterations  uint32 `yaml:"iterations" mapstructure:"iterations"`
+	Parallelism uint8  `yaml:"parallelism" mapstructure:"parallelism"`
+	SaltLength  uint32 `yaml:"salt_length" mapstructure:"salt_length"`
+	KeyLength   uint32 `yaml:"key_length" mapstructure:"key_length"`
+}
+
+// LockoutConfig 登录失败锁定配置
+type LockoutConfig struct {
+	Enabled     bool          `yaml:"enabled" mapstructure:"enabled"`
+	Prefix      string        `yaml:"prefix" mapstructure:"prefix"`
+	Threshold   int           `yaml:"threshold" mapstructure:"threshold"`
+	IPThreshold int           `yaml:"ip_threshold" mapstructure:"ip_threshold"`
+	Window      time.Duration `yaml:"window" mapstructure:"window"`
+	Cooldown    time.Duration `yaml:"cooldown" mapstructure:"cooldown"`
+}
+
+// PermissionConfig 权限标识缓存配置
+type PermissionConfig struct {
+	Prefix   string        `yaml:"prefix" mapstructure:"prefix"`
+	CacheTTL time.Duration `yaml:"cache_ttl" mapstructure:"cache_ttl"`
+}
+
+// BuiltinConfig 内置数据配置，超级管理员角色始终受保护
+type BuiltinConfig struct {
+	Roles []string `yaml:"roles" mapstructure:"roles"` // 额外受保护的角色编码
+	Users []string `yaml:"users" mapstructure:"users"` // 受保护的用户名
 }
 
 // TelemetryConfig 遥测配置
@@ -186,6 +235,7 @@ type TelemetryConfig struct {
 // OTLPConfig OTLP配置
 type OTLPConfig struct {
 	Endpoint string        `yaml:"endpoint" mapstructure:"endpoint"`
+	Protocol string        `yaml:"protocol" mapstructure:"protocol"`
 	Insecure bool          `yaml:"insecure" mapstructure:"insecure"`
 	Timeout  time.Duration `yaml:"timeout" mapstructure:"timeout"`
 }
@@ -193,12 +243,16 @@ type OTLPConfig struct {
 // TraceConfig 追踪配置
 type TraceConfig struct {
 	Enabled    bool                   `yaml:"enabled" mapstructure:"enabled"`
+	Exporter   string                 `yaml:"exporter" mapstructure:"exporter"`
 	Attributes map[string]interface{} `yaml:"attributes" mapstructure:"attributes"`
 }
 
 // MetricsConfig 指标配置
 type MetricsConfig struct {
 	Enabled  bool          `yaml:"enabled" mapstructure:"enabled"`
+	Exporter string        `yaml:"exporter" mapstructure:"exporter"`
+	Path     string        `yaml:"path" mapstructure:"path"`
+	Token    string        `yaml:"token" mapstructure:"token"`
 	Interval time.Duration `yaml:"interval" mapstructure:"interval"`
 }
 
@@ -212,24 +266,51 @@ type LogsConfig struct {
 type LogConfig struct {
 	Level       string      `yaml:"level" mapstructure:"level"`
 	Format      string      `yaml:"format" mapstructure:"format"`
+	Encoder     LogEncoder  `yaml:"encoder" mapstructure:"encoder"`
 	Output      LogOutput   `yaml:"output" mapstructure:"output"`
 	Rotate      LogRotate   `yaml:"rotate" mapstructure:"rotate"`
 	Caller      LogCaller   `yaml:"caller" mapstructure:"caller"`
 	Development bool        `yaml:"development" mapstructure:"development"`
 	Sampling    LogSampling `yaml:"sampling" mapstructure:"sampling"`
 	Fields      LogFields   `yaml:"fields" mapstructure:"fields"`
+	Redact      LogRedact   `yaml:"redact" mapstructure:"redact"`
 }
 
 // LogOutput 日志输出配置
 type LogOutput struct {
-	Console bool    `yaml:"console" mapstructure:"console"`
-	File    LogFile `yaml:"file" mapstructure:"file"`
+	Console       bool    `yaml:"console" mapstructure:"console"`
+	ConsoleFormat string  `yaml:"console_format" mapstructure:"console_format"`
+	File          LogFile `yaml:"file" mapstructure:"file"`
 }
 
 // LogFile 日志文件配置
 type LogFile struct {
 	Enabled bool   `yaml:"enabled" mapstructure:"enabled"`
 	Path    string `yaml:"path" mapstructure:"path"`
+	Format  string `yaml:"format" mapstructure:"format"`
+}
+
+// LogEncoder 日志编码配置
+type LogEncoder struct {
+	TimeLayout    string  `yaml:"time_layout" mapstructure:"time_layout"`
+	LevelEncoding string  `yaml:"level_encoding" mapstructure:"level_encoding"`
+	Keys          LogKeys `yaml:"keys" mapstructure:"keys"`
+}
+
+// LogKeys 日志字段名配置
+type LogKeys struct {
+	Time       string `yaml:"time" mapstructure:"time"`
+	Level      string `yaml:"level" mapstructure:"level"`
+	Name       string `yaml:"name" mapstructure:"name"`
+	Caller     string `yaml:"caller" mapstructure:"caller"`
+	Message    string `yaml:"message" mapstructure:"message"`
+	Stacktrace string `yaml:"stacktrace" mapstructure:"stacktrace"`
+}
+
+// LogRedact 日志脱敏配置，字段名忽略大小写、下划线与中划线
+type LogRedact struct {
+	Fields []string `yaml:"fields" mapstructure:"fields"` // 整体替换的字段名
+	Mask   []string `yaml:"mask" mapstructure:"mask"`     // 保留首尾部分字符的字段名
 }
 
 // LogRotate 日志轮转配置
@@ -269,6 +269,26 @@ func TestRedisClient(t *testing.T) {
 		}
 	})
 
+	// 测试自增并设置过期时间
+	t.Run("IncrExpire", func(t *testing.T) {
+		for i := int64(1); i <= 2; i++ {
+			n, err := client.IncrExpire(ctx, "test_counter", time.Minute)
+			if err != nil {
+				t.Fatalf("IncrExpire failed: %v", err)
+			}
+			if n != i {
+				t.Errorf("Expected %d, got %d", i, n)
+			}
+		}
+		ttl, err := client.TTL(ctx, "test_counter")
+		if err != nil {
+			t.Errorf("TTL failed: %v", err)
+		}
+		if ttl <= 0 || ttl > time.Minute {
+			t.Errorf("Expected ttl within a minute, got %v", ttl)
+		}
+	})
+
 	// 清理测试数据
-	client.Del(ctx, "test_key", "test_list")
+	client.Del(ctx, "test_key", "test_list", "test_counter")
 }
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const meterName = "simple/pkg/cache"
+
+// RegisterMetrics 注册默认客户端的连接池指标，在采集时读取 redis.PoolStats
+func RegisterMetrics() error {
+	meter := otel.Meter(meterName)
+
+	usage, err := meter.Int64ObservableGauge("redis.pool.connections",
+		metric.WithDescription("连接数，按状态区分 idle 与 used"), metric.WithUnit("{connection}"))
+	if err != nil {
+		return err
+	}
+	hits, err := meter.Int64ObservableCounter("redis.pool.hits",
+		metric.WithDescription("从连接池取到空闲连接的次数"), metric.WithUnit("{hit}"))
+	if err != nil {
+		return err
+	}
+	misses, err := meter.Int64ObservableCounter("redis.pool.misses",
+		metric.WithDescription("连接池中没有空闲连接的次数"), metric.WithUnit("{miss}"))
+	if err != nil {
+		return err
+	}
+	timeouts, err := meter.Int64ObservableCounter("redis.pool.timeouts",
+		metric.WithDescription("等待连接超时的次数"), metric.WithUnit("{timeout}"))
+	if err != nil {
+		return err
+	}
+	stale, err := meter.Int64ObservableCounter("redis.pool.stale_connections",
+		metric.WithDescription("被移除的失效连接数"), metric.WithUnit("{connection}"))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		if DefaultClient == nil {
+			return nil
+		}
+		stats := DefaultClient.PoolStats()
+
+		o.ObserveInt64(usage, int64(stats.IdleConns), metric.WithAttributes(attribute.String("state", "idle")))
+		o.ObserveInt64(usage, int64(stats.TotalConns-stats.IdleConns), metric.WithAttributes(attribute.String("state", "used")))
+		o.ObserveInt64(hits, int64(stats.Hits))
+		o.ObserveInt64(misses, int64(stats.Misses))
+		o.ObserveInt64(timeouts, int64(stats.Timeouts))
+		o.ObserveInt64(stale, int64(stats.StaleConns))
+		return nil
+	}, usage, hits, misses, timeouts, stale)
+	return err
+}
@@ -13,10 +13,14 @@ import (
 type RedisClient interface {
 	// 基本操作
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Get(ctx context.Context, key string) (string, error)
 	Del(ctx context.Context, keys ...string) error
 	Exists(ctx context.Context, key string) (bool, error)
 	Expire(ctx context.Context, key string, expiration time.Duration) error
+	TTL(ctx context.Context, key string) (time.Duration, error)
+	Incr(ctx context.Context, key string) (int64, error)
+	IncrExpire(ctx context.Context, key string, expiration time.Duration) (int64, error)
 
 	// 列表操作
 	LPush(ctx context.Context, key string, values ...interface{}) error
@@ -44,6 +48,9 @@ type RedisClient interface {
 	Subscribe(ctx context.Context, channels ...string) *redis.PubSub
 	Publish(ctx context.Context, channel string, message interface{}) error
 
+	// 连接池统计
+	PoolStats() *redis.PoolStats
+
 	// 关闭连接
 	Close() error
 }
@@ -122,6 +129,11 @@ func (r *redisClient) Set(ctx context.Context, key string, value interface{}, ex
 	return r.client.Set(ctx, key, value, expiration).Err()
 }
 
+// SetNX 键不存在时设置键值对，返回是否设置成功
+func (r *redisClient) SetNX(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, key, value, expiration).Result()
+}
+
 // Get 获取值
 func (r *redisClient) Get(ctx context.Context, key string) (string, error) {
 	return r.client.Get(ctx, key).Result()
@@ -143,6 +155,30 @@ func (r *redisClient) Expire(ctx context.Context, key string, expiration time.Du
 	return r.client.Expire(ctx, key, expiration).Err()
 }
 
+// TTL 获取剩余过期时间
+func (r *redisClient) TTL(ctx context.Context, key string) (time.Duration, error) {
+	return r.client.TTL(ctx, key).Result()
+}
+
+// Incr 自增计数
+func (r *redisClient) Incr(ctx context.Context, key string) (int64, error) {
+	return r.client.Incr(ctx, key).Result()
+}
+
+// 自增并在键没有过期时间时设置过期时间
+var incrExpireScript = redis.NewScript(`
+local n = redis.call('INCR', KEYS[1])
+if redis.call('PTTL', KEYS[1]) < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return n
+`)
+
+// IncrExpire 原子地自增计数，键没有过期时间时（如首次计数）设置过期时间
+func (r *redisClient) IncrExpire(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	return incrExpireScript.Run(ctx, r.client, []string{key}, expiration.Milliseconds()).Int64()
+}
+
 // LPush 左推入列表
 func (r *redisClient) LPush(ctx context.Context, key string, values ...interface{}) error {
 	return r.client.LPush(ctx, key, values...).Err()
@@ -223,6 +259,11 @@ func (r *redisClient) Publish(ctx context.Context, channel string, message inter
 	return r.client.Publish(ctx, channel, message).Err()
 }
 
+// PoolStats 获取连接池统计
+func (r *redisClient) PoolStats() *redis.PoolStats {
+	return r.client.PoolStats()
+}
+
 // Close 关闭连接
 func (r *redisClient) Close() error {
 	return r.client.Close()
@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"simple/model"
 	"strings"
 	"sync"
@@ -14,9 +15,17 @@ import (
 
 // Manager 配置管理器
 type Manager struct {
-	mutex sync.RWMutex
-	viper *viper.Viper
-	path  string
+	mutex    sync.RWMutex
+	viper    *viper.Viper
+	path     string
+	watchers []*watcher
+}
+
+// watcher 配置项变化的监听
+type watcher struct {
+	key   string
+	value interface{}
+	fn    func(value interface{})
 }
 
 // NewManager 创建配置管理器
@@ -82,18 +91,8 @@ func (m *Manager) LoadFile(cfg *model.Config, path ...string) error {
 	m.viper.WatchConfig()
 	m.viper.OnConfigChange(func(e fsnotify.Event) {
 		if e.Op == fsnotify.Write || e.Op == fsnotify.Create {
-			m.mutex.Lock()
-			defer m.mutex.Unlock()
-
-			fmt.Printf("配置文件发生变化: %s, 操作: %s\n", e.Name, e.Op.String())
-			// 重新加载配置
-			if err := m.viper.ReadInConfig(); err != nil {
-				fmt.Printf("重新加载配置失败: %v\n", err)
-				return
-			}
-			// 重新解析配置到结构体
-			if err := m.viper.Unmarshal(cfg); err != nil {
-				fmt.Printf("重新解析配置失败: %v\n", err)
+			for _, notify := range m.reload(e, cfg) {
+				notify()
 			}
 		}
 	})
@@ -101,6 +100,42 @@ func (m *Manager) LoadFile(cfg *model.Config, path ...string) error {
 	return nil
 }
 
+// 重新加载配置，返回发生变化的监听回调，在释放锁后执行
+func (m *Manager) reload(e fsnotify.Event, cfg *model.Config) []func() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	fmt.Printf("配置文件发生变化: %s, 操作: %s\n", e.Name, e.Op.String())
+	// 重新加载配置
+	if err := m.viper.ReadInConfig(); err != nil {
+		fmt.Printf("重新加载配置失败: %v\n", err)
+		return nil
+	}
+	// 重新解析配置到结构体
+	if err := m.viper.Unmarshal(cfg); err != nil {
+		fmt.Printf("重新解析配置失败: %v\n", err)
+	}
+
+	var notify []func()
+	for _, w := range m.watchers {
+		value := m.viper.Get(w.key)
+		if reflect.DeepEqual(value, w.value) {
+			continue
+		}
+		w.value = value
+		fn := w.fn
+		notify = append(notify, func() { fn(value) })
+	}
+	return notify
+}
+
+// Watch 监听配置项，配置文件重新加载后该项的值发生变化时回调
+func (m *Manager) Watch(key string, fn func(value interface{})) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.watchers = append(m.watchers, &watcher{key: key, value: m.viper.Get(key), fn: fn})
+}
+
 // Get 获取配置
 func (m *Manager) Get(key string) interface{} {
 	m.mutex.RLock()
@@ -237,6 +272,15 @@ func (m *Manager) GetJWTConfig() (*model.JWTConfig, error) {
 	return &cfg, nil
 }
 
+// GetAuthConfig 获取登录认证配置
+func (m *Manager) GetAuthConfig() (*model.AuthConfig, error) {
+	var cfg model.AuthConfig
+	if err := m.UnmarshalKey("auth", &cfg); err != nil {
+		return nil, fmt.Errorf("解析认证配置失败: %w", err)
+	}
+	return &cfg, nil
+}
+
 // GetTelemetryConfig 获取链路追踪配置
 func (m *Manager) GetTelemetryConfig() (*model.TelemetryConfig, error) {
 	var cfg model.TelemetryConfig
@@ -12,6 +12,7 @@ var (
 	ErrInvalidToken     = errors.New("无效的令牌") // 无效令牌
 	ErrTokenExpired     = errors.New("令牌已过期") // 令牌过期
 	ErrInvalidSignature = errors.New("无效的签名") // 签名无效
+	ErrTokenRevoked     = errors.New("令牌已失效") // 令牌已注销
 
 	// 请求相关错误
 	ErrBadRequest       = errors.New("无效的请求")  // 无效请求
@@ -27,6 +28,7 @@ var (
 	ErrInvalidPassword = errors.New("密码错误")  // 密码错误
 	ErrAccountLocked   = errors.New("账号已锁定") // 账号锁定
 	ErrOperationFailed = errors.New("操作失败")  // 操作失败
+	ErrUserDisabled    = errors.New("用户已禁用") // 用户已禁用
 
 	// 系统相关错误
 	ErrServer      = errors.New("系统错误") // 系统错误
@@ -39,14 +41,17 @@ var (
 	ErrRoleNameExists = errors.New("角色名称已存在")    // 角色名称已存在
 	ErrRoleCodeExists = errors.New("角色编码已存在")    // 角色编码已存在
 	ErrRoleSuperAdmin = errors.New("超级管理员不允许删除") // 超级管理员不允许删除
+	ErrRoleOutOfScope = errors.New("无权分配该角色")    // 角色超出数据范围
 
 	// 部门相关错误
-	ErrDepartmentNotFound    = errors.New("部门不存在")      // 部门不存在
-	ErrDepartmentNameExists  = errors.New("部门名称已存在")    // 部门名称已存在
-	ErrDepartmentCodeExists  = errors.New("部门编码已存在")    // 部门编码已存在
-	ErrDepartmentSuperAdmin  = errors.New("超级管理员不允许删除") // 超级管理员不允许删除
-	ErrDepartmentHasChildren = errors.New("存在子部门")      // 存在子部门
-	ErrDepartmentHasUsers    = errors.New("部门下存在用户")    // 部门下存在用户
+	ErrDepartmentNotFound     = errors.New("部门不存在")      // 部门不存在
+	ErrDepartmentNameExists   = errors.New("部门名称已存在")    // 部门名称已存在
+	ErrDepartmentCodeExists   = errors.New("部门编码已存在")    // 部门编码已存在
+	ErrDepartmentSuperAdmin   = errors.New("超级管理员不允许删除") // 超级管理员不允许删除
+	ErrDepartmentHasChildren  = errors.New("存在子部门")      // 存在子部门
+	ErrDepartmentHasUsers     = errors.New("部门下存在用户")    // 部门下存在用户
+	ErrDepartmentParent       = errors.New("上级部门无效")     // 上级部门无效
+	ErrDepartmentHasPositions = errors.New("部门下存在岗位")    // 部门下存在岗位
 
 	// 岗位相关错误
 	ErrPositionNotFound   = errors.New("岗位不存在")   // 岗位不存在
@@ -60,6 +65,12 @@ var (
 	ErrMenuParentNotFound = errors.New("父菜单不存在")   // 父菜单不存在
 	ErrMenuHasChildren    = errors.New("菜单下存在子菜单") // 菜单下存在子菜单
 	ErrMenuHasRoles       = errors.New("菜单被角色使用")  // 菜单被角色使用
+	ErrMenuParentInvalid  = errors.New("上级菜单无效")   // 上级菜单无效
+	ErrMenuNoPermission   = errors.New("按钮缺少权限标识") // 按钮缺少权限标识
+	ErrMenuNoComponent    = errors.New("菜单缺少组件")   // 菜单缺少组件
+
+	// 内置数据相关错误
+	ErrBuiltinImmutable = errors.New("内置数据不允许修改") // 内置数据不允许修改
 )
 
 // 错误码定义
@@ -74,6 +85,7 @@ var code = map[error]int{
 	ErrInvalidToken:     1003, // 无效令牌
 	ErrTokenExpired:     1004, // 令牌过期
 	ErrInvalidSignature: 1005, // 签名无效
+	ErrTokenRevoked:     1006, // 令牌已注销
 
 	// 请求相关错误码 (2000-2999)
 	ErrBadRequest:       2001, // 无效请求
@@ -89,6 +101,7 @@ var code = map[error]int{
 	ErrInvalidPassword: 3003, // 密码错误
 	ErrAccountLocked:   3004, // 账号锁定
 	ErrOperationFailed: 3005, // 操作失败
+	ErrUserDisabled:    3006, // 用户已禁用
 
 	// 系统相关错误码 (5000-5999)
 	ErrServer:      5001, // 系统错误
@@ -101,14 +114,17 @@ var code = map[error]int{
 	ErrRoleNameExists: 3102, // 角色名称已存在
 	ErrRoleCodeExists: 3103, // 角色编码已存在
 	ErrRoleSuperAdmin: 3104, // 超级管理员不允许删除
+	ErrRoleOutOfScope: 3105, // 角色超出数据范围
 
 	// 部门相关错误码 (3200-3300)
-	ErrDepartmentNotFound:    3201, // 部门不存在
-	ErrDepartmentNameExists:  3202, // 部门名称已存在
-	ErrDepartmentCodeExists:  3203, // 部门编码已存在
-	ErrDepartmentSuperAdmin:  3204, // 超级管理员不允许删除
-	ErrDepartmentHasChildren: 3205, // 存在子部门
-	ErrDepartmentHasUsers:    3206, // 部门下存在用户
+	ErrDepartmentNotFound:     3201, // 部门不存在
+	ErrDepartmentNameExists:   3202, // 部门名称已存在
+	ErrDepartmentCodeExists:   3203, // 部门编码已存在
+	ErrDepartmentSuperAdmin:   3204, // 超级管理员不允许删除
+	ErrDepartmentHasChildren:  3205, // 存在子部门
+	ErrDepartmentHasUsers:     3206, // 部门下存在用户
+	ErrDepartmentParent:       3207, // 上级部门无效
+	ErrDepartmentHasPositions: 3208, // 部门下存在岗位
 
 	// 岗位相关错误码 (3300-3400)
 	ErrPositionNotFound:   3301, // 岗位不存在
@@ -122,6 +138,12 @@ var code = map[error]int{
 	ErrMenuParentNotFound: 3403, // 父菜单不存在
 	ErrMenuHasChildren:    3404, // 菜单下存在子菜单
 	ErrMenuHasRoles:       3405, // 菜单被角色使用
+	ErrMenuParentInvalid:  3406, // 上级菜单无效
+	ErrMenuNoPermission:   3407, // 按钮缺少权限标识
+	ErrMenuNoComponent:    3408, // 菜单缺少组件
+
+	// 内置数据相关错误码 (3900-4000)
+	ErrBuiltinImmutable: 3901, // 内置数据不允许修改
 }
 
 // GC 获取错误码
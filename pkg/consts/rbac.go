@@ -0,0 +1,14 @@
+package consts
+
+/*
+   @NAME    : rbac
+   @author  : 清风
+   @desc    : 权限相关常量
+   @time    : 2025/3/21 20:10
+*/
+
+// SuperAdminRoleCode 超级管理员角色编码，拥有全部权限
+const SuperAdminRoleCode = "super-admin"
+
+// SuperAdminUsername 初始化数据中超级管理员的用户名，作为内置用户始终受保护
+const SuperAdminUsername = "admin"
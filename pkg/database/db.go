@@ -2,9 +2,8 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
-	"log"
-	"os"
 	"simple/model"
 	"time"
 
@@ -14,7 +13,6 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 	"gorm.io/plugin/dbresolver"
 )
 
@@ -40,31 +38,9 @@ func Init(config *model.DatabaseConfig) (*gorm.DB, error) {
 		return nil, fmt.Errorf("主数据库DSN不能为空")
 	}
 
-	// 设置日志
-	logConfig := logger.Config{
-		SlowThreshold:             time.Duration(config.Logger.SlowThreshold * float64(time.Second)),
-		LogLevel:                  parseLogLevel(config.Logger.Level),
-		IgnoreRecordNotFoundError: config.Logger.IgnoreRecordNotFound,
-		Colorful:                  config.Logger.Colorful,
-	}
-
-	// 配置日志输出目标
-	var logWriter = os.Stdout
-	if config.Logger.LogFilePath != "" {
-		file, err := os.OpenFile(config.Logger.LogFilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
-		if err == nil {
-			logWriter = file
-		} else {
-			log.Printf("无法打开日志文件 %s: %v, 使用标准输出代替", config.Logger.LogFilePath, err)
-		}
-	}
-
-	// 创建日志记录器
-	dbLogger := logger.New(log.New(logWriter, "\r\n", log.LstdFlags), logConfig)
-
 	// 创建GORM配置
 	gormConfig := &gorm.Config{
-		Logger: dbLogger,
+		Logger: NewLogger(&config.Logger),
 		NowFunc: func() time.Time {
 			return time.Now().Local()
 		},
@@ -85,18 +61,22 @@ func Init(config *model.DatabaseConfig) (*gorm.DB, error) {
 	sqlDB.SetMaxIdleConns(config.Write.MaxIdleConns)
 	sqlDB.SetMaxOpenConns(config.Write.MaxOpenConns)
 	sqlDB.SetConnMaxLifetime(config.Write.ConnMaxLifetime)
+	pools = []Pool{{Name: PoolWrite, DB: sqlDB}}
 
 	// 配置读写分离
 	if len(config.Read) > 0 {
+		// 主库直接复用写库连接池
 		resolverConfig := dbresolver.Config{}
 
-		// 配置主库
-		resolverConfig.Sources = []gorm.Dialector{mysql.Open(config.Write.DSN)}
-
-		// 配置从库
+		// 配置从库，自行打开连接池以便采集连接池指标
 		var replicas []gorm.Dialector
-		for _, read := range config.Read {
-			replicas = append(replicas, mysql.Open(read.DSN))
+		for i, read := range config.Read {
+			replicaDB, err := sql.Open("mysql", read.DSN)
+			if err != nil {
+				return nil, fmt.Errorf("连接从库失败: %w", err)
+			}
+			replicas = append(replicas, mysql.New(mysql.Config{Conn: replicaDB}))
+			pools = append(pools, Pool{Name: fmt.Sprintf("%s-%d", PoolRead, i), DB: replicaDB})
 		}
 		resolverConfig.Replicas = replicas
 
@@ -132,22 +112,6 @@ func Init(config *model.DatabaseConfig) (*gorm.DB, error) {
 	return db, nil
 }
 
-// 解析日志级别
-func parseLogLevel(level string) logger.LogLevel {
-	switch level {
-	case "silent":
-		return logger.Silent
-	case "error":
-		return logger.Error
-	case "warn":
-		return logger.Warn
-	case "info":
-		return logger.Info
-	default:
-		return logger.Info
-	}
-}
-
 // TracingPlugin 链路追踪插件
 type TracingPlugin struct {
 	tracer             trace.Tracer
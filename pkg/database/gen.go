@@ -147,10 +147,15 @@ func (g *Generator) SetupModelRelations() {
 	roleTable := "sys_role"
 	menuTable := "sys_menu"
 	userRoleTable := "sys_user_role"
+	roleMenuTable := "sys_role_menu"
+	roleDepartmentTable := "sys_role_department"
 
 	// 设置用户表的关联
 	userOpts := []gen.ModelOpt{
 		softDeleteField,
+		// 密码与盐值不参与序列化
+		gen.FieldJSONTag("password", "-"),
+		gen.FieldJSONTag("salt", "-"),
 		// 用户与部门的一对一关系 - 使用指针类型
 		gen.FieldNew("Department", "*Department", field.Tag{
 			"json": "department",
@@ -201,6 +206,16 @@ func (g *Generator) SetupModelRelations() {
 			"json": "users",
 			"gorm": fmt.Sprintf("many2many:%s;foreignKey:ID;joinForeignKey:RoleID;references:ID;joinReferences:UserID", userRoleTable),
 		}),
+		// 角色与菜单的多对多关系 - 使用指针数组类型
+		gen.FieldNew("Menus", "[]*Menu", field.Tag{
+			"json": "menus",
+			"gorm": fmt.Sprintf("many2many:%s;foreignKey:ID;joinForeignKey:RoleID;references:ID;joinReferences:MenuID", roleMenuTable),
+		}),
+		// 角色与自定义数据范围部门的多对多关系 - 使用指针数组类型
+		gen.FieldNew("Departments", "[]*Department", field.Tag{
+			"json": "departments",
+			"gorm": fmt.Sprintf("many2many:%s;foreignKey:ID;joinForeignKey:RoleID;references:ID;joinReferences:DepartmentID", roleDepartmentTable),
+		}),
 	}
 
 	// 设置菜单表的关联
@@ -231,6 +246,8 @@ func (g *Generator) SetupModelRelations() {
 	// 应用关联表模型
 	g.Gen.ApplyBasic(
 		g.Gen.GenerateModel(userRoleTable),
+		g.Gen.GenerateModel(roleMenuTable),
+		g.Gen.GenerateModel(roleDepartmentTable),
 	)
 }
 
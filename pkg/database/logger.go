@@ -0,0 +1,188 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"simple/model"
+	"simple/pkg/logger"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// gorm 日志名称，可通过 logger.SetNamedLevel("gorm", ...) 单独调整级别
+const loggerName = "gorm"
+
+// zapLogger 基于 zap 的 gorm 日志，输出 SQL、耗时、影响行数与调用位置
+type zapLogger struct {
+	log                  *zap.Logger
+	level                gormlogger.LogLevel
+	slowThreshold        time.Duration
+	ignoreRecordNotFound bool
+	traceFields          bool // 输出 trace_id 与 span_id
+	contextFields        bool // 输出上下文中附加的请求级字段
+	logParams            bool // 输出带参数值的SQL，关闭时只输出占位符
+}
+
+// NewLogger 创建 gorm 日志，需要在 logger.Init 之后调用
+func NewLogger(config *model.DBLoggerConfig) gormlogger.Interface {
+	return &zapLogger{
+		// 调用位置由 gorm 计算，关闭 zap 的调用者信息
+		log:                  logger.Log.Named(loggerName).WithOptions(zap.WithCaller(false)),
+		level:                parseLogLevel(config.Level),
+		slowThreshold:        time.Duration(config.SlowThreshold * float64(time.Second)),
+		ignoreRecordNotFound: config.IgnoreRecordNotFound,
+		traceFields:          config.TraceFields,
+		contextFields:        config.ContextFields,
+		logParams:            config.LogParams,
+	}
+}
+
+// LogMode 修改日志级别，返回新的实例
+func (l *zapLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	n := *l
+	n.level = level
+	return &n
+}
+
+// Info 输出info日志
+func (l *zapLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.write(ctx, zapcore.InfoLevel, fmt.Sprintf(msg, args...), callerFields)
+	}
+}
+
+// Warn 输出warn日志
+func (l *zapLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.write(ctx, zapcore.WarnLevel, fmt.Sprintf(msg, args...), callerFields)
+	}
+}
+
+// Error 输出error日志
+func (l *zapLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.write(ctx, zapcore.ErrorLevel, fmt.Sprintf(msg, args...), callerFields)
+	}
+}
+
+// Trace 输出SQL执行日志，出错时为 error，超过慢SQL阈值时为 warn，级别为 info 时输出所有SQL
+func (l *zapLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	fields := func(extra ...zap.Field) func() []zap.Field {
+		return func() []zap.Field {
+			sql, rows := fc()
+			fields := []zap.Field{
+				zap.String("sql", sql),
+				zap.Duration("elapsed", elapsed),
+			}
+			// 无法获取影响行数时为 -1
+			if rows >= 0 {
+				fields = append(fields, zap.Int64("rows", rows))
+			}
+			fields = append(fields, zap.String("caller", fileWithLineNum()))
+			return append(fields, extra...)
+		}
+	}
+
+	switch {
+	case err != nil && l.level >= gormlogger.Error &&
+		(!errors.Is(err, gorm.ErrRecordNotFound) || !l.ignoreRecordNotFound):
+		l.write(ctx, zapcore.ErrorLevel, "SQL执行失败", fields(zap.Error(err)))
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		l.write(ctx, zapcore.WarnLevel, "慢SQL", fields(zap.Duration("threshold", l.slowThreshold)))
+	case l.level >= gormlogger.Info:
+		l.write(ctx, zapcore.InfoLevel, "SQL", fields())
+	}
+}
+
+// ParamsFilter 实现 gorm.ParamsFilter，未开启 log_params 时丢弃参数，SQL 中保留占位符，
+// 避免密码哈希、手机号等参数值写入日志
+func (l *zapLogger) ParamsFilter(_ context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.logParams {
+		return sql, params
+	}
+	return sql, nil
+}
+
+// 级别未启用时直接返回，不生成SQL与调用位置等字段
+func (l *zapLogger) write(ctx context.Context, level zapcore.Level, msg string, fields func() []zap.Field) {
+	ce := l.log.Check(level, msg)
+	if ce == nil {
+		return
+	}
+	ce.Write(append(l.ctxFields(ctx), fields()...)...)
+}
+
+// 调用位置字段
+func callerFields() []zap.Field {
+	return []zap.Field{zap.String("caller", fileWithLineNum())}
+}
+
+// 按配置生成上下文字段
+func (l *zapLogger) ctxFields(ctx context.Context) []zap.Field {
+	if ctx == nil || (!l.traceFields && !l.contextFields) {
+		return nil
+	}
+
+	var fields []zap.Field
+	if l.traceFields {
+		fields = append(fields, logger.TraceFields(ctx)...)
+	}
+	if l.contextFields {
+		fields = append(fields, logger.ContextFields(ctx)...)
+	}
+	// 用于 OTel 日志关联，控制台与文件输出会忽略
+	return append(fields, logger.Context(ctx))
+}
+
+// 本包的源码目录，查找调用位置时跳过
+var sourceDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file) + "/"
+}()
+
+// 调用位置，跳过 gorm、本包与生成的查询代码
+func fileWithLineNum() string {
+	pcs := [32]uintptr{}
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.File, "gorm.io/") && !strings.HasPrefix(frame.File, sourceDir) &&
+			!strings.HasSuffix(frame.File, ".gen.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// 解析日志级别
+func parseLogLevel(level string) gormlogger.LogLevel {
+	switch level {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "warn":
+		return gormlogger.Warn
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Info
+	}
+}
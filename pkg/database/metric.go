@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// 连接池名称
+const (
+	PoolWrite = "write"
+	PoolRead  = "read"
+)
+
+const meterName = "simple/pkg/database"
+
+// Pool 数据库连接池
+type Pool struct {
+	Name string
+	DB   *sql.DB
+}
+
+// Init 创建的连接池，写库在前，从库按配置顺序命名为 read-0、read-1...
+var pools []Pool
+
+// 连接池指标的采集回调，关闭连接池时注销
+var registration metric.Registration
+
+// Pools 获取所有连接池
+func Pools() []Pool {
+	return pools
+}
+
+// RegisterMetrics 注册连接池指标，在采集时读取 sql.DBStats
+func RegisterMetrics() error {
+	meter := otel.Meter(meterName)
+
+	usage, err := meter.Int64ObservableGauge("db.client.connections.usage",
+		metric.WithDescription("连接数，按状态区分 idle 与 used"), metric.WithUnit("{connection}"))
+	if err != nil {
+		return err
+	}
+	maxOpen, err := meter.Int64ObservableGauge("db.client.connections.max",
+		metric.WithDescription("最大打开连接数"), metric.WithUnit("{connection}"))
+	if err != nil {
+		return err
+	}
+	waitCount, err := meter.Int64ObservableCounter("db.client.connections.wait_count",
+		metric.WithDescription("等待连接的总次数"), metric.WithUnit("{wait}"))
+	if err != nil {
+		return err
+	}
+	waitTime, err := meter.Float64ObservableCounter("db.client.connections.wait_time",
+		metric.WithDescription("等待连接的总时长"), metric.WithUnit("s"))
+	if err != nil {
+		return err
+	}
+	closed, err := meter.Int64ObservableCounter("db.client.connections.closed",
+		metric.WithDescription("因空闲或超时关闭的连接数"), metric.WithUnit("{connection}"))
+	if err != nil {
+		return err
+	}
+
+	registration, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		for _, pool := range pools {
+			stats := pool.DB.Stats()
+			name := attribute.String("db.client.connections.pool.name", pool.Name)
+
+			o.ObserveInt64(usage, int64(stats.Idle), metric.WithAttributes(name, attribute.String("state", "idle")))
+			o.ObserveInt64(usage, int64(stats.InUse), metric.WithAttributes(name, attribute.String("state", "used")))
+			o.ObserveInt64(maxOpen, int64(stats.MaxOpenConnections), metric.WithAttributes(name))
+			o.ObserveInt64(waitCount, stats.WaitCount, metric.WithAttributes(name))
+			o.ObserveFloat64(waitTime, stats.WaitDuration.Seconds(), metric.WithAttributes(name))
+			o.ObserveInt64(closed, stats.MaxIdleClosed+stats.MaxIdleTimeClosed, metric.WithAttributes(name, attribute.String("reason", "idle")))
+			o.ObserveInt64(closed, stats.MaxLifetimeClosed, metric.WithAttributes(name, attribute.String("reason", "lifetime")))
+		}
+		return nil
+	}, usage, maxOpen, waitCount, waitTime, closed)
+	return err
+}
+
+// Close 注销连接池指标并关闭所有连接池，包括自行打开的从库连接池
+func Close() error {
+	var errs []error
+	if registration != nil {
+		errs = append(errs, registration.Unregister())
+		registration = nil
+	}
+	for _, pool := range pools {
+		if err := pool.DB.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("关闭连接池 %s 失败: %w", pool.Name, err))
+		}
+	}
+	pools = nil
+	return errors.Join(errs...)
+}
@@ -0,0 +1,81 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"simple/pkg/cache"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+/*
+   @NAME    : blacklist
+   @author  : 清风
+   @desc    : 基于 Redis 的令牌黑名单
+   @time    : 2025/3/12 21:10
+*/
+
+// SetCache 设置黑名单使用的 Redis 客户端
+func (j *JWT) SetCache(client cache.RedisClient) {
+	j.cache = client
+}
+
+// 黑名单是否可用
+func (j *JWT) blacklistEnabled() bool {
+	return j.config.Blacklist.Enabled && j.cache != nil
+}
+
+// 黑名单键
+func (j *JWT) blacklistKey(jti string) string {
+	return j.config.Blacklist.Prefix + jti
+}
+
+// Revoke 将令牌加入黑名单，有效期为令牌剩余时间加上宽限期
+func (j *JWT) Revoke(ctx context.Context, claims *Claims) error {
+	if !j.blacklistEnabled() || claims.ID == "" {
+		return nil
+	}
+
+	ttl := j.revokeTTL(claims)
+	if ttl <= 0 {
+		return nil
+	}
+
+	return j.cache.Set(ctx, j.blacklistKey(claims.ID), j.now().UnixMilli(), ttl)
+}
+
+// 黑名单有效期：令牌剩余时间加上宽限期
+func (j *JWT) revokeTTL(claims *Claims) time.Duration {
+	ttl := j.config.Blacklist.GracePeriod
+	if claims.ExpiresAt != nil {
+		if remaining := claims.ExpiresAt.Sub(j.now()); remaining > 0 {
+			ttl += remaining
+		}
+	}
+	return ttl
+}
+
+// IsRevoked 判断令牌是否已被加入黑名单或所属令牌家族已被撤销
+func (j *JWT) IsRevoked(ctx context.Context, claims *Claims) (bool, error) {
+	if !j.blacklistEnabled() || claims.ID == "" {
+		return false, nil
+	}
+
+	_, err := j.cache.Get(ctx, j.blacklistKey(claims.ID))
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	if claims.FamilyID == "" {
+		return false, nil
+	}
+	active, err := j.cache.Exists(ctx, j.familyKey(claims.FamilyID))
+	if err != nil {
+		return false, err
+	}
+	return !active, nil
+}
@@ -0,0 +1,282 @@
+package jwt
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"simple/model"
+	"simple/pkg/cache"
+	"simple/pkg/consts"
+	"slices"
+	"strings"
+	"time"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+/*
+   @NAME    : jwt
+   @author  : 清风
+   @desc    : JWT 令牌签发与校验
+   @time    : 2025/3/11 21:40
+*/
+
+// 令牌类型
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+)
+
+// Identity 令牌持有者信息
+type Identity struct {
+	UserID   int64
+	UUID     string
+	Username string
+}
+
+// Claims 自定义声明
+type Claims struct {
+	UserID    int64  `json:"uid"`
+	UUID      string `json:"uuid,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"typ"`
+	FamilyID  string `json:"fid,omitempty"` // 令牌家族，同一次登录轮换出的令牌共享
+	gojwt.RegisteredClaims
+}
+
+// Identity 获取令牌持有者信息
+func (c *Claims) Identity() *Identity {
+	return &Identity{
+		UserID:   c.UserID,
+		UUID:     c.UUID,
+		Username: c.Username,
+	}
+}
+
+// TokenPair 访问令牌与刷新令牌
+type TokenPair struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	AccessExpiresAt  int64  `json:"access_expires_at"`
+	RefreshExpiresAt int64  `json:"refresh_expires_at"`
+}
+
+// JWT 令牌管理器
+type JWT struct {
+	config    *model.JWTConfig
+	method    gojwt.SigningMethod
+	signKey   interface{}
+	verifyKey interface{}
+	cache     cache.RedisClient
+	now       func() time.Time
+}
+
+// New 根据配置创建令牌管理器
+func New(config *model.JWTConfig) (*JWT, error) {
+	method := gojwt.GetSigningMethod(config.SigningMethod)
+	if method == nil {
+		return nil, fmt.Errorf("%w: 不支持的签名方法 %s", consts.ErrConfig, config.SigningMethod)
+	}
+
+	signKey, verifyKey, err := loadKeys(method, config.SigningKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JWT{
+		config:    config,
+		method:    method,
+		signKey:   signKey,
+		verifyKey: verifyKey,
+		now:       time.Now,
+	}, nil
+}
+
+// 根据签名方法加载密钥，非对称算法的 signing_key 可以是 PEM 内容或 PEM 文件路径
+func loadKeys(method gojwt.SigningMethod, key string) (interface{}, interface{}, error) {
+	if key == "" {
+		return nil, nil, fmt.Errorf("%w: 签名密钥不能为空", consts.ErrConfig)
+	}
+
+	switch method.(type) {
+	case *gojwt.SigningMethodHMAC:
+		return []byte(key), []byte(key), nil
+	case *gojwt.SigningMethodRSA, *gojwt.SigningMethodRSAPSS:
+		pem, err := readPEM(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		priv, err := gojwt.ParseRSAPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: 解析RSA私钥失败: %v", consts.ErrConfig, err)
+		}
+		return priv, &priv.PublicKey, nil
+	case *gojwt.SigningMethodECDSA:
+		pem, err := readPEM(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		priv, err := gojwt.ParseECPrivateKeyFromPEM(pem)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: 解析ECDSA私钥失败: %v", consts.ErrConfig, err)
+		}
+		return priv, &priv.PublicKey, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: 不支持的签名方法 %s", consts.ErrConfig, method.Alg())
+	}
+}
+
+// 读取 PEM 内容
+func readPEM(key string) ([]byte, error) {
+	if strings.Contains(key, "-----BEGIN") {
+		return []byte(key), nil
+	}
+	data, err := os.ReadFile(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: 读取密钥文件失败: %v", consts.ErrConfig, err)
+	}
+	return data, nil
+}
+
+// Config 获取JWT配置
+func (j *JWT) Config() *model.JWTConfig {
+	return j.config
+}
+
+// 签发同一令牌家族下的令牌对，返回刷新令牌的声明
+func (j *JWT) generatePair(identity *Identity, familyID string) (*TokenPair, *Claims, error) {
+	now := j.now()
+
+	access, accessClaims, err := j.generate(identity, familyID, TokenTypeAccess, now, j.config.Expiration.AccessToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	refresh, refreshClaims, err := j.generate(identity, familyID, TokenTypeRefresh, now, j.config.Expiration.RefreshToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:      access,
+		RefreshToken:     refresh,
+		AccessExpiresAt:  accessClaims.ExpiresAt.Unix(),
+		RefreshExpiresAt: refreshClaims.ExpiresAt.Unix(),
+	}, refreshClaims, nil
+}
+
+// 签发单个令牌
+func (j *JWT) generate(identity *Identity, familyID, tokenType string, now time.Time, ttl time.Duration) (string, *Claims, error) {
+	claims := &Claims{
+		UserID:    identity.UserID,
+		UUID:      identity.UUID,
+		Username:  identity.Username,
+		TokenType: tokenType,
+		FamilyID:  familyID,
+		RegisteredClaims: gojwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Issuer:    j.config.Issuer,
+			Subject:   j.config.Subject,
+			Audience:  j.config.Audience,
+			ExpiresAt: gojwt.NewNumericDate(now.Add(ttl)),
+			NotBefore: gojwt.NewNumericDate(now),
+			IssuedAt:  gojwt.NewNumericDate(now),
+		},
+	}
+
+	token, err := gojwt.NewWithClaims(j.method, claims).SignedString(j.signKey)
+	if err != nil {
+		return "", nil, fmt.Errorf("签发令牌失败: %w", err)
+	}
+	return token, claims, nil
+}
+
+// ParseAccessToken 解析并校验访问令牌
+func (j *JWT) ParseAccessToken(token string) (*Claims, error) {
+	return j.parse(token, TokenTypeAccess)
+}
+
+// ParseRefreshToken 解析并校验刷新令牌
+func (j *JWT) ParseRefreshToken(token string) (*Claims, error) {
+	return j.parse(token, TokenTypeRefresh)
+}
+
+// 解析令牌，先校验签名，再按配置逐项校验声明
+func (j *JWT) parse(token, tokenType string) (*Claims, error) {
+	parser := gojwt.NewParser(
+		gojwt.WithValidMethods([]string{j.method.Alg()}),
+		gojwt.WithoutClaimsValidation(),
+	)
+
+	claims := &Claims{}
+	_, err := parser.ParseWithClaims(token, claims, func(*gojwt.Token) (interface{}, error) {
+		return j.verifyKey, nil
+	})
+	if err != nil {
+		if errors.Is(err, gojwt.ErrTokenSignatureInvalid) {
+			return nil, consts.ErrInvalidSignature
+		}
+		return nil, consts.ErrInvalidToken
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, consts.ErrInvalidToken
+	}
+	if err = j.validate(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// 按 JWTOptions 校验声明
+func (j *JWT) validate(claims *Claims) error {
+	opts := j.config.Options
+	now := j.now()
+
+	if opts.VerifyExpiry {
+		if claims.ExpiresAt == nil {
+			return consts.ErrInvalidToken
+		}
+		if !now.Before(claims.ExpiresAt.Time) {
+			return consts.ErrTokenExpired
+		}
+	}
+	if opts.VerifyIssuedAt {
+		if claims.IssuedAt == nil || now.Before(claims.IssuedAt.Time) {
+			return consts.ErrInvalidToken
+		}
+	}
+	if opts.VerifyNotBefore {
+		if claims.NotBefore == nil || now.Before(claims.NotBefore.Time) {
+			return consts.ErrInvalidToken
+		}
+	}
+	if opts.VerifyIssuer && claims.Issuer != j.config.Issuer {
+		return consts.ErrInvalidToken
+	}
+	if opts.VerifySubject && claims.Subject != j.config.Subject {
+		return consts.ErrInvalidToken
+	}
+	if opts.VerifyAudience && !j.matchAudience(claims.Audience) {
+		return consts.ErrInvalidToken
+	}
+	return nil
+}
+
+// 令牌受众与配置受众存在交集即视为通过
+func (j *JWT) matchAudience(audience gojwt.ClaimStrings) bool {
+	for _, aud := range audience {
+		if slices.Contains(j.config.Audience, aud) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTokenError 判断是否为令牌本身的校验错误，其余错误通常来自缓存等基础设施
+func IsTokenError(err error) bool {
+	return errors.Is(err, consts.ErrInvalidToken) ||
+		errors.Is(err, consts.ErrTokenExpired) ||
+		errors.Is(err, consts.ErrInvalidSignature) ||
+		errors.Is(err, consts.ErrTokenRevoked)
+}
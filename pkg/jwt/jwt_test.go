@@ -0,0 +1,171 @@
+package jwt
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"simple/model"
+	"simple/pkg/consts"
+	"testing"
+	"time"
+)
+
+// 测试配置
+func testConfig() *model.JWTConfig {
+	return &model.JWTConfig{
+		SigningMethod: "HS256",
+		SigningKey:    "test-secret",
+		Expiration: model.JWTExpiration{
+			AccessToken:  time.Hour,
+			RefreshToken: 24 * time.Hour,
+		},
+		Issuer:   "simple",
+		Subject:  "auth",
+		Audience: []string{"web", "app"},
+		Options: model.JWTOptions{
+			VerifyExpiry:    true,
+			VerifyIssuedAt:  true,
+			VerifyIssuer:    true,
+			VerifySubject:   true,
+			VerifyAudience:  true,
+			VerifyNotBefore: true,
+		},
+	}
+}
+
+func mustNew(t *testing.T, config *model.JWTConfig) *JWT {
+	t.Helper()
+	j, err := New(config)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return j
+}
+
+// TestGenerateAndParse 测试签发与解析
+func TestGenerateAndParse(t *testing.T) {
+	j := mustNew(t, testConfig())
+	pair, err := j.IssueTokenPair(context.Background(), &Identity{UserID: 1, Username: "admin"})
+	if err != nil {
+		t.Fatalf("IssueTokenPair failed: %v", err)
+	}
+
+	t.Run("Access Token", func(t *testing.T) {
+		claims, err := j.ParseAccessToken(pair.AccessToken)
+		if err != nil {
+			t.Fatalf("ParseAccessToken failed: %v", err)
+		}
+		if claims.UserID != 1 || claims.Username != "admin" {
+			t.Errorf("unexpected claims: %+v", claims)
+		}
+		if claims.ID == "" {
+			t.Error("expected jti to be set")
+		}
+	})
+
+	t.Run("Token Type", func(t *testing.T) {
+		if _, err := j.ParseAccessToken(pair.RefreshToken); !errors.Is(err, consts.ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken for refresh token, got %v", err)
+		}
+		if _, err := j.ParseRefreshToken(pair.RefreshToken); err != nil {
+			t.Errorf("ParseRefreshToken failed: %v", err)
+		}
+	})
+
+	t.Run("Invalid Signature", func(t *testing.T) {
+		other := testConfig()
+		other.SigningKey = "other-secret"
+		if _, err := mustNew(t, other).ParseAccessToken(pair.AccessToken); !errors.Is(err, consts.ErrInvalidSignature) {
+			t.Errorf("expected ErrInvalidSignature, got %v", err)
+		}
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		if _, err := j.ParseAccessToken("not-a-token"); !errors.Is(err, consts.ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+}
+
+// TestVerifyOptions 测试各校验选项
+func TestVerifyOptions(t *testing.T) {
+	t.Run("Expiry", func(t *testing.T) {
+		j := mustNew(t, testConfig())
+		pair, _ := j.IssueTokenPair(context.Background(), &Identity{UserID: 1})
+
+		j.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		if _, err := j.ParseAccessToken(pair.AccessToken); !errors.Is(err, consts.ErrTokenExpired) {
+			t.Errorf("expected ErrTokenExpired, got %v", err)
+		}
+
+		j.config.Options.VerifyExpiry = false
+		if _, err := j.ParseAccessToken(pair.AccessToken); err != nil {
+			t.Errorf("expected expired token to pass when VerifyExpiry=false, got %v", err)
+		}
+	})
+
+	t.Run("Issuer", func(t *testing.T) {
+		issuer := testConfig()
+		issuer.Issuer = "other"
+		pair, _ := mustNew(t, issuer).IssueTokenPair(context.Background(), &Identity{UserID: 1})
+
+		j := mustNew(t, testConfig())
+		if _, err := j.ParseAccessToken(pair.AccessToken); !errors.Is(err, consts.ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+		j.config.Options.VerifyIssuer = false
+		if _, err := j.ParseAccessToken(pair.AccessToken); err != nil {
+			t.Errorf("expected token to pass when VerifyIssuer=false, got %v", err)
+		}
+	})
+
+	t.Run("Audience", func(t *testing.T) {
+		audience := testConfig()
+		audience.Audience = []string{"mini"}
+		pair, _ := mustNew(t, audience).IssueTokenPair(context.Background(), &Identity{UserID: 1})
+
+		j := mustNew(t, testConfig())
+		if _, err := j.ParseAccessToken(pair.AccessToken); !errors.Is(err, consts.ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+
+	t.Run("Not Before", func(t *testing.T) {
+		j := mustNew(t, testConfig())
+		pair, _ := j.IssueTokenPair(context.Background(), &Identity{UserID: 1})
+
+		j.now = func() time.Time { return time.Now().Add(-time.Minute) }
+		if _, err := j.ParseAccessToken(pair.AccessToken); !errors.Is(err, consts.ErrInvalidToken) {
+			t.Errorf("expected ErrInvalidToken, got %v", err)
+		}
+	})
+}
+
+// TestECDSA 测试非对称签名
+func TestECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := testConfig()
+	config.SigningMethod = "ES256"
+	config.SigningKey = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+
+	j := mustNew(t, config)
+	pair, err := j.IssueTokenPair(context.Background(), &Identity{UserID: 2})
+	if err != nil {
+		t.Fatalf("IssueTokenPair failed: %v", err)
+	}
+	if _, err := j.ParseAccessToken(pair.AccessToken); err != nil {
+		t.Errorf("ParseAccessToken failed: %v", err)
+	}
+}
@@ -0,0 +1,248 @@
+package jwt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : refresh
+   @author  : 清风
+   @desc    : 令牌刷新与令牌家族
+   @time    : 2025/3/13 20:50
+*/
+
+// 等待并发刷新结果的次数与间隔
+const (
+	rotatedRetries  = 20
+	rotatedInterval = 50 * time.Millisecond
+)
+
+// 令牌家族记录当前有效的刷新令牌，家族被删除后其下所有令牌立即失效。
+// 未启用黑名单时不记录服务端状态，刷新仅做无状态的令牌签发。
+
+// 令牌家族键
+func (j *JWT) familyKey(familyID string) string {
+	return j.config.Blacklist.Prefix + "family:" + familyID
+}
+
+// 轮换结果键，宽限期内保存刷新令牌换得的新令牌对
+func (j *JWT) rotatedKey(jti string) string {
+	return j.config.Blacklist.Prefix + "rotated:" + jti
+}
+
+// 续签标记键，保证同一个访问令牌只续签一次
+func (j *JWT) renewKey(jti string) string {
+	return j.config.Blacklist.Prefix + "renew:" + jti
+}
+
+// IssueTokenPair 签发令牌对并开启新的令牌家族，用于登录
+func (j *JWT) IssueTokenPair(ctx context.Context, identity *Identity) (*TokenPair, error) {
+	pair, refresh, err := j.generatePair(identity, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+	if err = j.saveFamily(ctx, refresh); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// Refresh 使用刷新令牌换取新的令牌对。
+// 不允许重用时刷新令牌只能使用一次，宽限期内的并发刷新返回同一组令牌，
+// 超过宽限期后已使用过的刷新令牌再次出现视为泄露，撤销整个令牌家族。
+func (j *JWT) Refresh(ctx context.Context, token string) (*TokenPair, error) {
+	claims, err := j.ParseRefreshToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if !j.blacklistEnabled() {
+		pair, _, err := j.generatePair(claims.Identity(), claims.FamilyID)
+		return pair, err
+	}
+
+	active, err := j.cache.Exists(ctx, j.familyKey(claims.FamilyID))
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, consts.ErrTokenRevoked
+	}
+
+	if j.config.Refresh.Reuse {
+		revoked, err := j.IsRevoked(ctx, claims)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, consts.ErrTokenRevoked
+		}
+	} else {
+		// 原子地将刷新令牌标记为已使用，失败说明该令牌已被使用过
+		ttl := j.revokeTTL(claims)
+		if ttl <= 0 {
+			ttl = j.config.Expiration.RefreshToken
+		}
+		consumed, err := j.cache.SetNX(ctx, j.blacklistKey(claims.ID), j.now().UnixMilli(), ttl)
+		if err != nil {
+			return nil, err
+		}
+		if !consumed {
+			return j.reused(ctx, claims)
+		}
+	}
+	return j.rotate(ctx, claims)
+}
+
+// 签发新的令牌对，宽限期内保存在已使用的刷新令牌下，供并发的刷新请求取得同一组令牌
+func (j *JWT) rotate(ctx context.Context, claims *Claims) (*TokenPair, error) {
+	pair, refresh, err := j.generatePair(claims.Identity(), claims.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if err = j.saveFamily(ctx, refresh); err != nil {
+		return nil, err
+	}
+	if err = j.saveRotated(ctx, claims.ID, pair); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// 宽限期内将新的令牌对保存在已作废的刷新令牌下
+func (j *JWT) saveRotated(ctx context.Context, jti string, pair *TokenPair) error {
+	grace := j.config.Refresh.ReuseGrace
+	if grace <= 0 || j.config.Refresh.Reuse || jti == "" {
+		return nil
+	}
+	data, err := json.Marshal(pair)
+	if err != nil {
+		return err
+	}
+	return j.cache.Set(ctx, j.rotatedKey(jti), data, grace)
+}
+
+// 已使用的刷新令牌再次出现：宽限期内返回轮换得到的令牌对，超过宽限期视为泄露，撤销整个令牌家族
+func (j *JWT) reused(ctx context.Context, claims *Claims) (*TokenPair, error) {
+	if grace := j.config.Refresh.ReuseGrace; grace > 0 {
+		consumedAt, err := j.cache.Get(ctx, j.blacklistKey(claims.ID))
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+		at, _ := strconv.ParseInt(consumedAt, 10, 64)
+		if at > 0 && j.now().Sub(time.UnixMilli(at)) <= grace {
+			return j.rotated(ctx, claims)
+		}
+	}
+
+	logger.WarnContext(ctx, "检测到刷新令牌重用，撤销令牌家族",
+		zap.Int64("uid", claims.UserID), zap.String("jti", claims.ID), zap.String("fid", claims.FamilyID))
+	if err := j.RevokeFamily(ctx, claims.FamilyID); err != nil {
+		return nil, err
+	}
+	return nil, consts.ErrTokenRevoked
+}
+
+// 读取宽限期内保存的令牌对，先到的请求可能尚未保存完成，短暂等待
+func (j *JWT) rotated(ctx context.Context, claims *Claims) (*TokenPair, error) {
+	for i := 0; i < rotatedRetries; i++ {
+		data, err := j.cache.Get(ctx, j.rotatedKey(claims.ID))
+		if err == nil {
+			pair := &TokenPair{}
+			if err = json.Unmarshal([]byte(data), pair); err != nil {
+				return nil, err
+			}
+			return pair, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(rotatedInterval):
+		}
+	}
+	// 先到的请求签发失败，拒绝本次请求但不撤销家族
+	return nil, consts.ErrTokenRevoked
+}
+
+// Renew 为即将过期的访问令牌签发新的令牌对。
+// 同一个访问令牌只续签一次，已被其它请求续签时返回 nil。
+// 不允许重用时，家族中原有的刷新令牌随之作废。
+func (j *JWT) Renew(ctx context.Context, claims *Claims) (*TokenPair, error) {
+	if !j.blacklistEnabled() {
+		pair, _, err := j.generatePair(claims.Identity(), claims.FamilyID)
+		return pair, err
+	}
+
+	ttl := time.Minute
+	if claims.ExpiresAt != nil {
+		if remaining := claims.ExpiresAt.Sub(j.now()); remaining > ttl {
+			ttl = remaining
+		}
+	}
+	ok, err := j.cache.SetNX(ctx, j.renewKey(claims.ID), j.now().UnixMilli(), ttl)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	// 家族中被作废的刷新令牌，宽限期内仍可换取本次签发的令牌对
+	var current string
+	if !j.config.Refresh.Reuse && claims.FamilyID != "" {
+		current, err = j.cache.Get(ctx, j.familyKey(claims.FamilyID))
+		if err != nil {
+			if errors.Is(err, redis.Nil) {
+				return nil, consts.ErrTokenRevoked
+			}
+			return nil, err
+		}
+		ttl := j.config.Expiration.RefreshToken + j.config.Blacklist.GracePeriod
+		if err = j.cache.Set(ctx, j.blacklistKey(current), j.now().UnixMilli(), ttl); err != nil {
+			return nil, err
+		}
+	}
+
+	familyID := claims.FamilyID
+	if familyID == "" {
+		familyID = uuid.NewString()
+	}
+	pair, refresh, err := j.generatePair(claims.Identity(), familyID)
+	if err != nil {
+		return nil, err
+	}
+	if err = j.saveFamily(ctx, refresh); err != nil {
+		return nil, err
+	}
+	if err = j.saveRotated(ctx, current, pair); err != nil {
+		return nil, err
+	}
+	return pair, nil
+}
+
+// RevokeFamily 撤销令牌家族，家族下的所有令牌立即失效
+func (j *JWT) RevokeFamily(ctx context.Context, familyID string) error {
+	if !j.blacklistEnabled() || familyID == "" {
+		return nil
+	}
+	return j.cache.Del(ctx, j.familyKey(familyID))
+}
+
+// 记录家族当前的刷新令牌，有效期与刷新令牌一致
+func (j *JWT) saveFamily(ctx context.Context, refresh *Claims) error {
+	if !j.blacklistEnabled() {
+		return nil
+	}
+	ttl := refresh.ExpiresAt.Sub(j.now())
+	return j.cache.Set(ctx, j.familyKey(refresh.FamilyID), refresh.ID, ttl)
+}
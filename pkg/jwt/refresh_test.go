@@ -0,0 +1,178 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"simple/pkg/cache"
+	"simple/pkg/consts"
+	"simple/pkg/logger"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// memoryCache 测试用的内存缓存，只实现令牌刷新用到的方法，不处理过期
+type memoryCache struct {
+	cache.RedisClient
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{data: map[string]string{}}
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value interface{}, _ time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if b, ok := value.([]byte); ok {
+		c.data[key] = string(b)
+	} else {
+		c.data[key] = fmt.Sprint(value)
+	}
+	return nil
+}
+
+func (c *memoryCache) SetNX(_ context.Context, key string, value interface{}, _ time.Duration) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.data[key]; ok {
+		return false, nil
+	}
+	c.data[key] = fmt.Sprint(value)
+	return true, nil
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.data[key]
+	if !ok {
+		return "", redis.Nil
+	}
+	return value, nil
+}
+
+func (c *memoryCache) Del(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.data, key)
+	}
+	return nil
+}
+
+func (c *memoryCache) Exists(_ context.Context, key string) (bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.data[key]
+	return ok, nil
+}
+
+// 启用黑名单、不允许重用刷新令牌的令牌管理器
+func newRefreshJWT(t *testing.T) *JWT {
+	config := testConfig()
+	config.Blacklist.Enabled = true
+	config.Blacklist.Prefix = "jwt:"
+	config.Blacklist.GracePeriod = time.Hour
+	config.Refresh.ReuseGrace = 30 * time.Second
+
+	logger.Log = zap.NewNop()
+	j := mustNew(t, config)
+	j.SetCache(newMemoryCache())
+	return j
+}
+
+// TestRefreshConcurrent 测试宽限期内并发使用同一刷新令牌返回同一组令牌
+func TestRefreshConcurrent(t *testing.T) {
+	j := newRefreshJWT(t)
+	ctx := context.Background()
+	pair, err := j.IssueTokenPair(ctx, &Identity{UserID: 1})
+	if err != nil {
+		t.Fatalf("IssueTokenPair failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	pairs := make([]*TokenPair, 2)
+	errs := make([]error, 2)
+	for i := range pairs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			pairs[i], errs[i] = j.Refresh(ctx, pair.RefreshToken)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Refresh %d failed: %v", i, err)
+		}
+	}
+	if pairs[0].RefreshToken != pairs[1].RefreshToken || pairs[0].AccessToken != pairs[1].AccessToken {
+		t.Error("expected concurrent refreshes to return the same token pair")
+	}
+
+	claims, err := j.ParseAccessToken(pairs[0].AccessToken)
+	if err != nil {
+		t.Fatalf("ParseAccessToken failed: %v", err)
+	}
+	if revoked, err := j.IsRevoked(ctx, claims); err != nil || revoked {
+		t.Errorf("expected family to stay active, revoked=%v err=%v", revoked, err)
+	}
+}
+
+// TestRefreshReuseAfterGrace 测试超过宽限期后重用刷新令牌撤销令牌家族
+func TestRefreshReuseAfterGrace(t *testing.T) {
+	j := newRefreshJWT(t)
+	ctx := context.Background()
+	pair, err := j.IssueTokenPair(ctx, &Identity{UserID: 1})
+	if err != nil {
+		t.Fatalf("IssueTokenPair failed: %v", err)
+	}
+	next, err := j.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	j.now = func() time.Time { return time.Now().Add(time.Minute) }
+	if _, err = j.Refresh(ctx, pair.RefreshToken); !errors.Is(err, consts.ErrTokenRevoked) {
+		t.Fatalf("expected ErrTokenRevoked, got %v", err)
+	}
+	if _, err = j.Refresh(ctx, next.RefreshToken); !errors.Is(err, consts.ErrTokenRevoked) {
+		t.Errorf("expected family to be revoked, got %v", err)
+	}
+}
+
+// TestRenewThenRefresh 测试续签后宽限期内使用旧的刷新令牌返回续签得到的令牌对
+func TestRenewThenRefresh(t *testing.T) {
+	j := newRefreshJWT(t)
+	ctx := context.Background()
+	pair, err := j.IssueTokenPair(ctx, &Identity{UserID: 1})
+	if err != nil {
+		t.Fatalf("IssueTokenPair failed: %v", err)
+	}
+	claims, err := j.ParseAccessToken(pair.AccessToken)
+	if err != nil {
+		t.Fatalf("ParseAccessToken failed: %v", err)
+	}
+	renewed, err := j.Renew(ctx, claims)
+	if err != nil || renewed == nil {
+		t.Fatalf("Renew failed: %v", err)
+	}
+
+	got, err := j.Refresh(ctx, pair.RefreshToken)
+	if err != nil {
+		t.Fatalf("Refresh with old token failed: %v", err)
+	}
+	if got.RefreshToken != renewed.RefreshToken || got.AccessToken != renewed.AccessToken {
+		t.Error("expected old refresh token to return the renewed token pair")
+	}
+	if _, err = j.Refresh(ctx, renewed.RefreshToken); err != nil {
+		t.Errorf("expected family to stay active, got %v", err)
+	}
+}
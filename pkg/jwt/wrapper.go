@@ -0,0 +1,45 @@
+package jwt
+
+import (
+	"context"
+	"simple/model"
+	"simple/pkg/cache"
+)
+
+var (
+	// DefaultJWT 默认令牌管理器
+	DefaultJWT *JWT
+)
+
+// Setup 设置默认令牌管理器，client 用于令牌黑名单
+func Setup(config *model.JWTConfig, client cache.RedisClient) error {
+	j, err := New(config)
+	if err != nil {
+		return err
+	}
+	j.SetCache(client)
+	DefaultJWT = j
+	return nil
+}
+
+// Default 获取默认令牌管理器
+func Default() *JWT {
+	if DefaultJWT == nil {
+		panic("jwt not initialized, call Setup first")
+	}
+	return DefaultJWT
+}
+
+// 上下文键类型定义
+type contextKey struct{}
+
+// NewContext 将令牌声明写入上下文
+func NewContext(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, contextKey{}, claims)
+}
+
+// FromContext 从上下文中读取令牌声明
+func FromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(contextKey{}).(*Claims)
+	return claims, ok
+}
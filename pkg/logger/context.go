@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// 上下文日志字段的键
+const (
+	TraceIDKey   = "trace_id"
+	SpanIDKey    = "span_id"
+	RequestIDKey = "request_id"
+	UserIDKey    = "user_id"
+)
+
+// 请求级字段在上下文中的键
+type fieldsKey struct{}
+
+// WithFields 在上下文中附加请求级字段，之后通过 Ctx 输出的日志都会带上这些字段
+func WithFields(ctx context.Context, fields ...zap.Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	old := ContextFields(ctx)
+	merged := make([]zap.Field, 0, len(old)+len(fields))
+	merged = append(merged, old...)
+	merged = append(merged, fields...)
+	return context.WithValue(ctx, fieldsKey{}, merged)
+}
+
+// WithRequestID 在上下文中附加请求ID
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return WithFields(ctx, zap.String(RequestIDKey, requestID))
+}
+
+// WithUserID 在上下文中附加当前用户ID
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return WithFields(ctx, zap.Int64(UserIDKey, userID))
+}
+
+// Ctx 返回带有上下文字段的日志实例，包括 trace_id、span_id 与 WithFields 附加的字段
+func Ctx(ctx context.Context) *zap.Logger {
+	if ctx == nil {
+		return Log
+	}
+
+	fields := ContextFields(ctx)
+	all := make([]zap.Field, 0, len(fields)+3)
+	all = append(all, TraceFields(ctx)...)
+	all = append(all, fields...)
+	all = append(all, Context(ctx))
+	return Log.With(all...)
+}
+
+// TraceFields 上下文中的 trace_id 与 span_id，没有有效的 span 时返回nil
+func TraceFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return nil
+	}
+	return []zap.Field{zap.String(TraceIDKey, sc.TraceID().String()), zap.String(SpanIDKey, sc.SpanID().String())}
+}
+
+// ContextFields 上下文中通过 WithFields 附加的字段
+func ContextFields(ctx context.Context) []zap.Field {
+	fields, _ := ctx.Value(fieldsKey{}).([]zap.Field)
+	return fields
+}
+
+// DebugContext 带请求上下文的debug日志
+func DebugContext(ctx context.Context, msg string, fields ...zap.Field) {
+	Ctx(ctx).Debug(msg, fields...)
+}
+
+// InfoContext 带请求上下文的info日志
+func InfoContext(ctx context.Context, msg string, fields ...zap.Field) {
+	Ctx(ctx).Info(msg, fields...)
+}
+
+// WarnContext 带请求上下文的warn日志
+func WarnContext(ctx context.Context, msg string, fields ...zap.Field) {
+	Ctx(ctx).Warn(msg, fields...)
+}
+
+// ErrorContext 带请求上下文的error日志
+func ErrorContext(ctx context.Context, msg string, fields ...zap.Field) {
+	Ctx(ctx).Error(msg, fields...)
+}
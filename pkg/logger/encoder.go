@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"fmt"
+	"simple/model"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// 日志格式
+const (
+	FormatConsole = "console"
+	FormatJSON    = "json"
+)
+
+// 配置中表示不输出该字段的键名
+const omitKey = "-"
+
+// 输出格式，优先使用输出单独配置的格式，其次使用全局格式，最后使用默认格式
+func outputFormat(override, global, def string) (string, error) {
+	format := override
+	if format == "" {
+		format = global
+	}
+	if format == "" {
+		format = def
+	}
+	switch strings.ToLower(format) {
+	case FormatConsole:
+		return FormatConsole, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("未知的日志格式: %s", format)
+	}
+}
+
+// 创建编码器，彩色级别只用于控制台的 console 格式
+func newEncoder(config *model.LogConfig, format string, console bool) (zapcore.Encoder, error) {
+	color := console && format == FormatConsole
+	encoderConfig, err := newEncoderConfig(config, color)
+	if err != nil {
+		return nil, err
+	}
+	if format == FormatJSON {
+		return zapcore.NewJSONEncoder(encoderConfig), nil
+	}
+	return zapcore.NewConsoleEncoder(encoderConfig), nil
+}
+
+// 编码器配置
+func newEncoderConfig(config *model.LogConfig, color bool) (zapcore.EncoderConfig, error) {
+	keys := config.Encoder.Keys
+	encoderConfig := zapcore.EncoderConfig{
+		TimeKey:        keyName(keys.Time, "time"),
+		LevelKey:       keyName(keys.Level, "level"),
+		NameKey:        keyName(keys.Name, "logger"),
+		CallerKey:      keyName(keys.Caller, "caller"),
+		FunctionKey:    zapcore.OmitKey,
+		MessageKey:     keyName(keys.Message, "msg"),
+		StacktraceKey:  keyName(keys.Stacktrace, "stacktrace"),
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeTime:     timeEncoder(config.Encoder.TimeLayout),
+		EncodeDuration: zapcore.SecondsDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+
+	encodeLevel, err := levelEncoder(config.Encoder.LevelEncoding, config.Development, color)
+	if err != nil {
+		return encoderConfig, err
+	}
+	encoderConfig.EncodeLevel = encodeLevel
+	return encoderConfig, nil
+}
+
+// 字段名，留空使用默认值
+func keyName(key, def string) string {
+	switch key {
+	case "":
+		return def
+	case omitKey:
+		return zapcore.OmitKey
+	default:
+		return key
+	}
+}
+
+// 时间编码，支持 zap 内置的名称与 Go 时间布局
+func timeEncoder(layout string) zapcore.TimeEncoder {
+	switch layout {
+	case "", "iso8601", "ISO8601":
+		return zapcore.ISO8601TimeEncoder
+	case "epoch":
+		return zapcore.EpochTimeEncoder
+	case "rfc3339", "RFC3339", "rfc3339nano", "RFC3339Nano", "millis", "nanos":
+		var encoder zapcore.TimeEncoder
+		_ = encoder.UnmarshalText([]byte(layout))
+		return encoder
+	default:
+		return zapcore.TimeEncoderOfLayout(layout)
+	}
+}
+
+// 级别编码，不支持彩色的输出使用对应的无色编码
+func levelEncoder(encoding string, development, color bool) (zapcore.LevelEncoder, error) {
+	if encoding == "" {
+		encoding = "capital"
+		if development {
+			encoding = "capitalColor"
+		}
+	}
+
+	switch encoding {
+	case "capital":
+		return zapcore.CapitalLevelEncoder, nil
+	case "lowercase":
+		return zapcore.LowercaseLevelEncoder, nil
+	case "capitalColor":
+		if color {
+			return zapcore.CapitalColorLevelEncoder, nil
+		}
+		return zapcore.CapitalLevelEncoder, nil
+	case "color":
+		if color {
+			return zapcore.LowercaseColorLevelEncoder, nil
+		}
+		return zapcore.LowercaseLevelEncoder, nil
+	default:
+		return nil, fmt.Errorf("未知的日志级别格式: %s", encoding)
+	}
+}
@@ -0,0 +1,301 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// 运行时日志级别，Init 时使用配置的级别
+var levels = newLevelController(zapcore.InfoLevel)
+
+// LevelState 当前日志级别
+type LevelState struct {
+	Level      string                `json:"level"`      // 全局级别
+	Configured string                `json:"configured"` // 配置文件中的级别
+	RevertAt   *time.Time            `json:"revert_at"`  // 全局级别恢复为配置级别的时间
+	Overrides  map[string]NamedLevel `json:"overrides"`  // 按日志名称覆盖的级别
+}
+
+// NamedLevel 按日志名称覆盖的级别
+type NamedLevel struct {
+	Level    string     `json:"level"`     // 级别
+	ExpireAt *time.Time `json:"expire_at"` // 自动移除的时间
+}
+
+// SetLevel 修改全局日志级别，ttl 大于0时到期后恢复为配置文件中的级别
+func SetLevel(level string, ttl time.Duration) error {
+	l, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	levels.set(l, ttl)
+	return nil
+}
+
+// SetConfigLevel 配置文件中的级别变化时调用，同时取消临时修改的全局级别
+func SetConfigLevel(level string) error {
+	l, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	levels.configure(l)
+	return nil
+}
+
+// SetNamedLevel 按日志名称覆盖级别，对该名称及其子名称生效，ttl 大于0时到期后自动移除
+func SetNamedLevel(name, level string, ttl time.Duration) error {
+	l, err := parseLevel(level)
+	if err != nil {
+		return err
+	}
+	levels.setNamed(name, l, ttl)
+	return nil
+}
+
+// ResetLevel 恢复全局级别为配置文件中的级别
+func ResetLevel() {
+	levels.configure(levels.configuredLevel())
+}
+
+// ResetNamedLevel 移除日志名称的级别覆盖
+func ResetNamedLevel(name string) {
+	levels.resetNamed(name)
+}
+
+// Levels 获取当前日志级别
+func Levels() LevelState {
+	return levels.state()
+}
+
+// AtomicLevel 获取全局级别，不包含按名称覆盖的级别，直接修改时不会自动恢复
+func AtomicLevel() zap.AtomicLevel {
+	return levels.current
+}
+
+// levelController 全局级别与按名称覆盖的级别
+type levelController struct {
+	mu         sync.RWMutex
+	configured zapcore.Level
+	current    zap.AtomicLevel // 全局级别
+	revertAt   time.Time
+	revert     *time.Timer
+	overrides  map[string]*override
+	named      atomic.Bool   // 是否存在名称覆盖，没有时无需加锁
+	min        zapcore.Level // 名称覆盖中的最低级别
+}
+
+// 按名称覆盖的级别
+type override struct {
+	level    zapcore.Level
+	expireAt time.Time
+	timer    *time.Timer
+}
+
+func newLevelController(level zapcore.Level) *levelController {
+	return &levelController{
+		configured: level,
+		current:    zap.NewAtomicLevelAt(level),
+		overrides:  map[string]*override{},
+	}
+}
+
+// 修改配置级别，取消临时的全局级别
+func (c *levelController) configure(level zapcore.Level) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopRevert()
+	c.configured = level
+	c.current.SetLevel(level)
+	c.updateMin()
+}
+
+// 临时或永久修改全局级别
+func (c *levelController) set(level zapcore.Level, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.stopRevert()
+	c.current.SetLevel(level)
+	if ttl > 0 {
+		c.revertAt = time.Now().Add(ttl)
+		var timer *time.Timer
+		timer = time.AfterFunc(ttl, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			// 期间再次修改过级别时忽略
+			if c.revert != timer {
+				return
+			}
+			c.revert = nil
+			c.revertAt = time.Time{}
+			c.current.SetLevel(c.configured)
+			c.updateMin()
+		})
+		c.revert = timer
+	}
+	c.updateMin()
+}
+
+// 设置名称覆盖的级别
+func (c *levelController) setNamed(name string, level zapcore.Level, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.overrides[name]; ok && old.timer != nil {
+		old.timer.Stop()
+	}
+	o := &override{level: level}
+	if ttl > 0 {
+		o.expireAt = time.Now().Add(ttl)
+		o.timer = time.AfterFunc(ttl, func() {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			if c.overrides[name] == o {
+				delete(c.overrides, name)
+				c.updateMin()
+			}
+		})
+	}
+	c.overrides[name] = o
+	c.updateMin()
+}
+
+// 移除名称覆盖的级别
+func (c *levelController) resetNamed(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if o, ok := c.overrides[name]; ok {
+		if o.timer != nil {
+			o.timer.Stop()
+		}
+		delete(c.overrides, name)
+		c.updateMin()
+	}
+}
+
+func (c *levelController) configuredLevel() zapcore.Level {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.configured
+}
+
+// 停止全局级别的恢复计时
+func (c *levelController) stopRevert() {
+	if c.revert != nil {
+		c.revert.Stop()
+		c.revert = nil
+	}
+	c.revertAt = time.Time{}
+}
+
+// 重新计算名称覆盖中的最低级别
+func (c *levelController) updateMin() {
+	c.named.Store(len(c.overrides) > 0)
+	c.min = zapcore.InvalidLevel
+	for _, o := range c.overrides {
+		if c.min == zapcore.InvalidLevel || o.level < c.min {
+			c.min = o.level
+		}
+	}
+}
+
+// 全局级别或任一名称覆盖可能输出该级别
+func (c *levelController) Enabled(level zapcore.Level) bool {
+	if c.current.Enabled(level) {
+		return true
+	}
+	if !c.named.Load() {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.min != zapcore.InvalidLevel && level >= c.min
+}
+
+// 按日志名称判断是否输出，名称按 "." 分段匹配最长的覆盖
+func (c *levelController) allowed(name string, level zapcore.Level) bool {
+	if !c.named.Load() || name == "" {
+		return c.current.Enabled(level)
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for n := name; n != ""; {
+		if o, ok := c.overrides[n]; ok {
+			return level >= o.level
+		}
+		i := strings.LastIndexByte(n, '.')
+		if i < 0 {
+			break
+		}
+		n = n[:i]
+	}
+	return c.current.Enabled(level)
+}
+
+// 当前状态
+func (c *levelController) state() LevelState {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	s := LevelState{
+		Level:      c.current.String(),
+		Configured: c.configured.String(),
+		Overrides:  make(map[string]NamedLevel, len(c.overrides)),
+	}
+	if !c.revertAt.IsZero() {
+		revertAt := c.revertAt
+		s.RevertAt = &revertAt
+	}
+	for name, o := range c.overrides {
+		named := NamedLevel{Level: o.level.String()}
+		if !o.expireAt.IsZero() {
+			expireAt := o.expireAt
+			named.ExpireAt = &expireAt
+		}
+		s.Overrides[name] = named
+	}
+	return s
+}
+
+// levelCore 按运行时级别过滤日志，包裹控制台与文件输出
+type levelCore struct {
+	zapcore.Core
+	levels *levelController
+}
+
+// Enabled 判断级别是否可能输出
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return c.levels.Enabled(level)
+}
+
+// Level 返回最低可能输出的级别
+func (c *levelCore) Level() zapcore.Level {
+	level := c.levels.current.Level()
+	c.levels.mu.RLock()
+	defer c.levels.mu.RUnlock()
+	if c.levels.min != zapcore.InvalidLevel && c.levels.min < level {
+		return c.levels.min
+	}
+	return level
+}
+
+// With 添加字段
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+// Check 按日志名称与级别判断是否输出
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.allowed(ent.LoggerName, ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
+}
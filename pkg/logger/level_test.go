@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// 创建使用独立级别控制的测试日志
+func newTestLogger(level zapcore.Level) (*zap.Logger, *levelController, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ctl := newLevelController(level)
+	return zap.New(&levelCore{Core: core, levels: ctl}), ctl, logs
+}
+
+// TestLevelTTL 测试临时修改全局级别到期后恢复
+func TestLevelTTL(t *testing.T) {
+	log, ctl, logs := newTestLogger(zapcore.InfoLevel)
+
+	log.Debug("before")
+	ctl.set(zapcore.DebugLevel, 50*time.Millisecond)
+	log.Debug("during")
+	time.Sleep(100 * time.Millisecond)
+	log.Debug("after")
+
+	if got := logs.Len(); got != 1 || logs.All()[0].Message != "during" {
+		t.Errorf("logs = %v", logs.All())
+	}
+	if state := ctl.state(); state.Level != "info" || state.RevertAt != nil {
+		t.Errorf("state = %+v", state)
+	}
+}
+
+// TestNamedLevel 测试按名称覆盖级别，子名称继承覆盖
+func TestNamedLevel(t *testing.T) {
+	log, ctl, logs := newTestLogger(zapcore.WarnLevel)
+	ctl.setNamed("gorm", zapcore.DebugLevel, 0)
+
+	log.Info("root")
+	log.Named("gorm").Debug("gorm")
+	log.Named("gorm").Named("slow").Debug("gorm.slow")
+	log.Named("gormx").Debug("gormx")
+
+	var got []string
+	for _, entry := range logs.All() {
+		got = append(got, entry.Message)
+	}
+	if len(got) != 2 || got[0] != "gorm" || got[1] != "gorm.slow" {
+		t.Errorf("messages = %v", got)
+	}
+
+	ctl.resetNamed("gorm")
+	log.Named("gorm").Debug("reset")
+	if logs.Len() != 2 {
+		t.Errorf("override not removed, logs = %d", logs.Len())
+	}
+}
+
+// TestConfigureCancelsTTL 测试配置级别变化时取消临时级别
+func TestConfigureCancelsTTL(t *testing.T) {
+	_, ctl, _ := newTestLogger(zapcore.InfoLevel)
+
+	ctl.set(zapcore.DebugLevel, time.Hour)
+	ctl.configure(zapcore.ErrorLevel)
+
+	if state := ctl.state(); state.Level != "error" || state.Configured != "error" || state.RevertAt != nil {
+		t.Errorf("state = %+v", state)
+	}
+}
@@ -31,79 +31,58 @@ func Init(config *model.LogConfig) error {
 	return nil
 }
 
-// NewLogger 创建一个新的日志实例
+// NewLogger 创建一个新的日志实例，日志级别由全局的运行时级别控制，可通过 SetLevel 等方法动态修改
 func NewLogger(config *model.LogConfig) (*zap.Logger, error) {
 	// 解析日志级别
 	level, err := parseLevel(config.Level)
 	if err != nil {
 		return nil, err
 	}
+	levels.configure(level)
+	redaction = newRedactor(&config.Redact)
 
 	// 创建Core
 	var cores []zapcore.Core
 
-	// 控制台输出 - 始终使用控制台格式
+	// 控制台输出，默认使用控制台格式
 	if config.Output.Console {
-		// 控制台编码器配置
-		consoleEncoderConfig := zapcore.EncoderConfig{
-			TimeKey:        "time",
-			LevelKey:       "level",
-			NameKey:        "logger",
-			CallerKey:      "caller",
-			FunctionKey:    zapcore.OmitKey,
-			MessageKey:     "msg",
-			StacktraceKey:  "stacktrace",
-			LineEnding:     zapcore.DefaultLineEnding,
-			EncodeLevel:    zapcore.CapitalLevelEncoder,
-			EncodeTime:     zapcore.ISO8601TimeEncoder,
-			EncodeDuration: zapcore.SecondsDurationEncoder,
-			EncodeCaller:   zapcore.ShortCallerEncoder,
+		format, err := outputFormat(config.Output.ConsoleFormat, config.Format, FormatConsole)
+		if err != nil {
+			return nil, err
 		}
-
-		// 开发模式下使用彩色级别
-		if config.Development {
-			consoleEncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		consoleEncoder, err := newEncoder(config, format, true)
+		if err != nil {
+			return nil, err
 		}
-
-		consoleEncoder := zapcore.NewConsoleEncoder(consoleEncoderConfig)
 		consoleCore := zapcore.NewCore(
 			consoleEncoder,
 			zapcore.Lock(os.Stdout),
-			level,
+			zapcore.DebugLevel,
 		)
-		cores = append(cores, consoleCore)
+		cores = append(cores, &skipContextCore{Core: consoleCore})
 	}
 
-	// 文件输出 - 始终使用JSON格式
+	// 文件输出，默认使用JSON格式，不使用彩色编码
 	if config.Output.File.Enabled && config.Output.File.Path != "" {
-		// JSON编码器配置 - 不使用颜色编码
-		jsonEncoderConfig := zapcore.EncoderConfig{
-			TimeKey:        "time",
-			LevelKey:       "level",
-			NameKey:        "logger",
-			CallerKey:      "caller",
-			FunctionKey:    zapcore.OmitKey,
-			MessageKey:     "msg",
-			StacktraceKey:  "stacktrace",
-			LineEnding:     zapcore.DefaultLineEnding,
-			EncodeLevel:    zapcore.CapitalLevelEncoder, // 不使用彩色编码
-			EncodeTime:     zapcore.ISO8601TimeEncoder,
-			EncodeDuration: zapcore.SecondsDurationEncoder,
-			EncodeCaller:   zapcore.ShortCallerEncoder,
+		format, err := outputFormat(config.Output.File.Format, config.Format, FormatJSON)
+		if err != nil {
+			return nil, err
+		}
+		fileEncoder, err := newEncoder(config, format, false)
+		if err != nil {
+			return nil, err
 		}
-
-		jsonEncoder := zapcore.NewJSONEncoder(jsonEncoderConfig)
 		writer := getLogWriter(config)
 		fileCore := zapcore.NewCore(
-			jsonEncoder,
+			fileEncoder,
 			zapcore.AddSync(writer),
-			level,
+			zapcore.DebugLevel,
 		)
-		cores = append(cores, fileCore)
+		cores = append(cores, &skipContextCore{Core: fileCore})
 	}
 
-	// 合并cores
-	core := zapcore.NewTee(cores...)
+	// 合并cores，编码前统一脱敏，由运行时级别统一过滤
+	core := &levelCore{Core: &redactCore{Core: zapcore.NewTee(cores...), r: redaction}, levels: levels}
 
 	// 创建Logger
 	var zapOptions []zap.Option
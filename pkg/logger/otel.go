@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"context"
+	"simple/model"
+
+	"go.opentelemetry.io/contrib/bridges/otelzap"
+	"go.opentelemetry.io/otel/log/global"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// OTel 日志的 instrumentation scope 名称
+const otelScope = "simple/pkg/logger"
+
+// 上下文字段的键
+const contextKey = "ctx"
+
+// Context 携带请求上下文的字段，OTel 日志据此关联 trace 与 span，控制台与文件输出会忽略该字段
+func Context(ctx context.Context) zap.Field {
+	return zap.Any(contextKey, ctx)
+}
+
+// EnableOTel 将 Logs.Level 及以上的日志同时发送到全局 LoggerProvider，需在 telemetry.Setup 之后调用
+func EnableOTel(config *model.LogsConfig) error {
+	level, err := parseLevel(config.Level)
+	if err != nil {
+		return err
+	}
+
+	core, err := zapcore.NewIncreaseLevelCore(
+		&redactCore{Core: otelzap.NewCore(otelScope, otelzap.WithLoggerProvider(global.GetLoggerProvider())), r: redaction},
+		level,
+	)
+	if err != nil {
+		return err
+	}
+
+	Log = Log.WithOptions(zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(c, core)
+	}))
+	Sugar = Log.Sugar()
+	return nil
+}
+
+// skipContextCore 忽略上下文字段，避免控制台与文件输出中出现无意义的上下文内容
+type skipContextCore struct {
+	zapcore.Core
+}
+
+// With 添加字段
+func (c *skipContextCore) With(fields []zapcore.Field) zapcore.Core {
+	return &skipContextCore{Core: c.Core.With(skipContext(fields))}
+}
+
+// Check 判断是否输出
+func (c *skipContextCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 写入日志
+func (c *skipContextCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, skipContext(fields))
+}
+
+// 过滤上下文字段
+func skipContext(fields []zapcore.Field) []zapcore.Field {
+	for i, field := range fields {
+		if _, ok := field.Interface.(context.Context); ok {
+			kept := make([]zapcore.Field, 0, len(fields)-1)
+			kept = append(kept, fields[:i]...)
+			for _, f := range fields[i+1:] {
+				if _, ok := f.Interface.(context.Context); !ok {
+					kept = append(kept, f)
+				}
+			}
+			return kept
+		}
+	}
+	return fields
+}
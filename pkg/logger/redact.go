@@ -0,0 +1,639 @@
+package logger
+
+import (
+	"context"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"simple/model"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+	"unsafe"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// 结构体字段的脱敏标签，如 `log:"redact"`、`log:"mask"`
+const redactTag = "log"
+
+// 脱敏后的替换值
+const redacted = "******"
+
+// 脱敏规则
+type rule uint8
+
+const (
+	ruleNone   rule = iota
+	ruleRedact      // 整体替换
+	ruleMask        // 保留首尾部分字符
+)
+
+// 当前的脱敏配置，Init 时使用配置的字段名
+var redaction = newRedactor(nil)
+
+// redactor 按字段名与结构体标签脱敏
+type redactor struct {
+	names map[string]rule // 归一化后的字段名
+	types sync.Map        // reflect.Type -> *typeInfo
+}
+
+func newRedactor(config *model.LogRedact) *redactor {
+	r := &redactor{names: map[string]rule{}}
+	if config == nil {
+		return r
+	}
+	for _, name := range config.Mask {
+		r.names[normalize(name)] = ruleMask
+	}
+	for _, name := range config.Fields {
+		r.names[normalize(name)] = ruleRedact
+	}
+	return r
+}
+
+var separators = strings.NewReplacer("_", "", "-", "")
+
+// 字段名忽略大小写、下划线与中划线，password、Password 与 pass_word 视为相同
+func normalize(name string) string {
+	name = strings.ToLower(name)
+	if strings.ContainsAny(name, "_-") {
+		name = separators.Replace(name)
+	}
+	return name
+}
+
+// 按字段名匹配规则
+func (r *redactor) lookup(name string) rule {
+	if len(r.names) == 0 {
+		return ruleNone
+	}
+	return r.names[normalize(name)]
+}
+
+// 脱敏日志字段，没有需要处理的字段时返回原切片
+func (r *redactor) fields(fields []zapcore.Field) []zapcore.Field {
+	var out []zapcore.Field
+	for i, field := range fields {
+		f, changed := r.field(field)
+		if !changed {
+			if out != nil {
+				out = append(out, field)
+			}
+			continue
+		}
+		if out == nil {
+			out = make([]zapcore.Field, i, len(fields))
+			copy(out, fields[:i])
+		}
+		out = append(out, f)
+	}
+	if out == nil {
+		return fields
+	}
+	return out
+}
+
+// 脱敏单个字段
+func (r *redactor) field(field zapcore.Field) (zapcore.Field, bool) {
+	// 上下文字段用于 OTel 日志关联，不做处理
+	if _, ok := field.Interface.(context.Context); ok {
+		return field, false
+	}
+
+	switch rl := r.lookup(field.Key); rl {
+	case ruleRedact:
+		return zap.String(field.Key, redacted), true
+	case ruleMask:
+		if s, ok := fieldString(field); ok {
+			return zap.String(field.Key, mask(s)), true
+		}
+		return zap.String(field.Key, redacted), true
+	}
+
+	switch field.Type {
+	case zapcore.ReflectType:
+		if field.Interface == nil {
+			return field, false
+		}
+		v := reflect.ValueOf(field.Interface)
+		if !r.needs(v.Type()) {
+			return field, false
+		}
+		return zap.Any(field.Key, r.value(v)), true
+	case zapcore.ObjectMarshalerType:
+		return zap.Object(field.Key, &redactObject{m: field.Interface.(zapcore.ObjectMarshaler), r: r}), true
+	case zapcore.ArrayMarshalerType:
+		return zap.Array(field.Key, &redactArray{m: field.Interface.(zapcore.ArrayMarshaler), r: r}), true
+	}
+	return field, false
+}
+
+// 字段的字符串值，用于部分脱敏
+func fieldString(field zapcore.Field) (string, bool) {
+	switch field.Type {
+	case zapcore.StringType:
+		return field.String, true
+	case zapcore.StringerType:
+		return fmt.Sprint(field.Interface), true
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return fmt.Sprint(field.Integer), true
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return fmt.Sprint(uint64(field.Integer)), true
+	case zapcore.ReflectType:
+		return valueString(reflect.ValueOf(field.Interface))
+	}
+	return "", false
+}
+
+// 部分脱敏，邮箱保留域名，其余保留首尾各三分之一
+func mask(s string) string {
+	if i := strings.LastIndexByte(s, '@'); i > 0 {
+		return mask(s[:i]) + s[i:]
+	}
+	n := utf8.RuneCountInString(s)
+	keep := n / 3
+	if n <= 2 {
+		keep = 0
+	}
+	runes := []rune(s)
+	return string(runes[:keep]) + strings.Repeat("*", n-2*keep) + string(runes[n-keep:])
+}
+
+// 结构体字段
+type fieldInfo struct {
+	index     int
+	name      string
+	omitEmpty bool
+	embedded  bool // 匿名嵌入且没有指定名称，字段展开到外层
+	rule      rule
+}
+
+// 类型的脱敏信息
+type typeInfo struct {
+	needs  bool
+	fields []fieldInfo
+}
+
+// 是否按 json 的方式自行编码
+func marshalsItself(t reflect.Type) bool {
+	jsonMarshaler := reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	return t.Implements(jsonMarshaler) || t.Implements(textMarshaler) ||
+		reflect.PointerTo(t).Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(textMarshaler)
+}
+
+// 类型是否可能包含需要脱敏的内容
+func (r *redactor) needs(t reflect.Type) bool {
+	return r.typeInfo(t).needs
+}
+
+func (r *redactor) typeInfo(t reflect.Type) *typeInfo {
+	if info, ok := r.types.Load(t); ok {
+		return info.(*typeInfo)
+	}
+	info := &typeInfo{}
+	if t.Kind() == reflect.Struct {
+		info.fields = r.structFields(t)
+	}
+	info.needs = r.inspect(t, map[reflect.Type]bool{})
+	actual, _ := r.types.LoadOrStore(t, info)
+	return actual.(*typeInfo)
+}
+
+// 递归检查类型，visiting 用于处理自引用的类型
+func (r *redactor) inspect(t reflect.Type, visiting map[reflect.Type]bool) bool {
+	if visiting[t] || marshalsItself(t) {
+		return false
+	}
+	visiting[t] = true
+	defer delete(visiting, t)
+
+	switch t.Kind() {
+	case reflect.Interface:
+		// 只能在运行时判断
+		return true
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return r.inspect(t.Elem(), visiting)
+	case reflect.Map:
+		return (t.Key().Kind() == reflect.String && len(r.names) > 0) || r.inspect(t.Elem(), visiting)
+	case reflect.Struct:
+		for _, f := range r.structFields(t) {
+			if f.rule != ruleNone || r.inspect(t.Field(f.index).Type, visiting) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 按 json 标签解析结构体字段，忽略未导出与 json:"-" 的字段，与 json 一样展开未导出的嵌入结构体
+func (r *redactor) structFields(t reflect.Type) []fieldInfo {
+	var fields []fieldInfo
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		f := fieldInfo{index: i, name: name, omitEmpty: strings.Contains(opts, "omitempty")}
+
+		ft := sf.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		f.embedded = sf.Anonymous && name == "" && ft.Kind() == reflect.Struct
+		if !sf.IsExported() && (!f.embedded || sf.Type.Kind() == reflect.Pointer) {
+			continue
+		}
+		if f.name == "" {
+			f.name = sf.Name
+		}
+
+		switch sf.Tag.Get(redactTag) {
+		case "redact":
+			f.rule = ruleRedact
+		case "mask":
+			f.rule = ruleMask
+		default:
+			if f.rule = r.lookup(f.name); f.rule == ruleNone {
+				f.rule = r.lookup(sf.Name)
+			}
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// 转换为脱敏后的值，结构体与 map 转换为 ObjectMarshaler，切片转换为 ArrayMarshaler
+func (r *redactor) value(v reflect.Value) interface{} {
+	if !v.IsValid() {
+		return nil
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !r.needs(v.Type()) {
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		return &redactStruct{v: v, r: r}
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		return &redactMap{v: v, r: r}
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		return &redactSlice{v: v, r: r}
+	case reflect.Array:
+		return &redactSlice{v: v, r: r}
+	}
+	return v.Interface()
+}
+
+// 按规则编码值
+func (r *redactor) add(enc zapcore.ObjectEncoder, key string, v reflect.Value, rl rule) error {
+	switch rl {
+	case ruleRedact:
+		if isNil(v) {
+			return enc.AddReflected(key, nil)
+		}
+		enc.AddString(key, redacted)
+		return nil
+	case ruleMask:
+		if isNil(v) {
+			return enc.AddReflected(key, nil)
+		}
+		if s, ok := valueString(v); ok {
+			enc.AddString(key, mask(s))
+		} else {
+			enc.AddString(key, redacted)
+		}
+		return nil
+	}
+
+	switch value := r.value(v).(type) {
+	case zapcore.ObjectMarshaler:
+		return enc.AddObject(key, value)
+	case zapcore.ArrayMarshaler:
+		return enc.AddArray(key, value)
+	default:
+		return enc.AddReflected(key, value)
+	}
+}
+
+// 基础类型的字符串值
+func valueString(v reflect.Value) (string, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(v.Interface()), true
+	}
+	return "", false
+}
+
+func isNil(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return v.IsNil()
+	case reflect.Invalid:
+		return true
+	}
+	return false
+}
+
+// 与 encoding/json 的 omitempty 判断一致
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
+
+// redactStruct 按字段编码结构体
+type redactStruct struct {
+	v reflect.Value
+	r *redactor
+}
+
+// MarshalLogObject 编码字段
+func (s *redactStruct) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range s.r.typeInfo(s.v.Type()).fields {
+		fv := s.field(f.index)
+		if f.embedded {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					continue
+				}
+				fv = fv.Elem()
+			}
+			if err := (&redactStruct{v: fv, r: s.r}).MarshalLogObject(enc); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.omitEmpty && isEmpty(fv) {
+			continue
+		}
+		if err := s.r.add(enc, f.name, fv, f.rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 字段值，未导出的嵌入结构体通过地址读取，使其中的导出字段可以取值
+func (s *redactStruct) field(i int) reflect.Value {
+	fv := s.v.Field(i)
+	if fv.CanInterface() {
+		return fv
+	}
+	if !s.v.CanAddr() {
+		v := reflect.New(s.v.Type()).Elem()
+		v.Set(s.v)
+		s.v = v
+		fv = v.Field(i)
+	}
+	return reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+}
+
+// redactMap 按键编码 map，键名匹配时脱敏
+type redactMap struct {
+	v reflect.Value
+	r *redactor
+}
+
+// MarshalLogObject 按键排序后编码
+func (m *redactMap) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	keys := m.v.MapKeys()
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = fmt.Sprint(k.Interface())
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return names[order[i]] < names[order[j]] })
+
+	for _, i := range order {
+		if err := m.r.add(enc, names[i], m.v.MapIndex(keys[i]), m.r.lookup(names[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactSlice 按元素编码切片与数组
+type redactSlice struct {
+	v reflect.Value
+	r *redactor
+}
+
+// MarshalLogArray 编码元素
+func (s *redactSlice) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for i := 0; i < s.v.Len(); i++ {
+		var err error
+		switch value := s.r.value(s.v.Index(i)).(type) {
+		case zapcore.ObjectMarshaler:
+			err = enc.AppendObject(value)
+		case zapcore.ArrayMarshaler:
+			err = enc.AppendArray(value)
+		default:
+			err = enc.AppendReflected(value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// redactObject 包裹 ObjectMarshaler，脱敏其写入的字段
+type redactObject struct {
+	m zapcore.ObjectMarshaler
+	r *redactor
+}
+
+// MarshalLogObject 使用脱敏的编码器编码
+func (o *redactObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	return o.m.MarshalLogObject(&redactEncoder{ObjectEncoder: enc, r: o.r})
+}
+
+// redactArray 包裹 ArrayMarshaler，脱敏其中的对象
+type redactArray struct {
+	m zapcore.ArrayMarshaler
+	r *redactor
+}
+
+// MarshalLogArray 使用脱敏的编码器编码
+func (a *redactArray) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	return a.m.MarshalLogArray(&redactArrayEncoder{ArrayEncoder: enc, r: a.r})
+}
+
+// redactEncoder 按字段名脱敏写入的字段
+type redactEncoder struct {
+	zapcore.ObjectEncoder
+	r *redactor
+}
+
+// 字段名匹配时写入脱敏值
+func (e *redactEncoder) replace(key string, value func() (string, bool)) bool {
+	switch e.r.lookup(key) {
+	case ruleRedact:
+		e.ObjectEncoder.AddString(key, redacted)
+		return true
+	case ruleMask:
+		if s, ok := value(); ok {
+			e.ObjectEncoder.AddString(key, mask(s))
+		} else {
+			e.ObjectEncoder.AddString(key, redacted)
+		}
+		return true
+	}
+	return false
+}
+
+// AddString 写入字符串
+func (e *redactEncoder) AddString(key, value string) {
+	if !e.replace(key, func() (string, bool) { return value, true }) {
+		e.ObjectEncoder.AddString(key, value)
+	}
+}
+
+// AddByteString 写入字节字符串
+func (e *redactEncoder) AddByteString(key string, value []byte) {
+	if !e.replace(key, func() (string, bool) { return string(value), true }) {
+		e.ObjectEncoder.AddByteString(key, value)
+	}
+}
+
+// AddBinary 写入二进制数据
+func (e *redactEncoder) AddBinary(key string, value []byte) {
+	if !e.replace(key, func() (string, bool) { return "", false }) {
+		e.ObjectEncoder.AddBinary(key, value)
+	}
+}
+
+// AddInt64 写入整数
+func (e *redactEncoder) AddInt64(key string, value int64) {
+	if !e.replace(key, func() (string, bool) { return fmt.Sprint(value), true }) {
+		e.ObjectEncoder.AddInt64(key, value)
+	}
+}
+
+// AddInt 写入整数
+func (e *redactEncoder) AddInt(key string, value int) {
+	e.AddInt64(key, int64(value))
+}
+
+// AddUint64 写入整数
+func (e *redactEncoder) AddUint64(key string, value uint64) {
+	if !e.replace(key, func() (string, bool) { return fmt.Sprint(value), true }) {
+		e.ObjectEncoder.AddUint64(key, value)
+	}
+}
+
+// AddReflected 写入任意值，结构体等按字段脱敏
+func (e *redactEncoder) AddReflected(key string, value interface{}) error {
+	return e.r.add(e.ObjectEncoder, key, reflect.ValueOf(value), e.r.lookup(key))
+}
+
+// AddObject 写入对象
+func (e *redactEncoder) AddObject(key string, m zapcore.ObjectMarshaler) error {
+	if e.replace(key, func() (string, bool) { return "", false }) {
+		return nil
+	}
+	return e.ObjectEncoder.AddObject(key, &redactObject{m: m, r: e.r})
+}
+
+// AddArray 写入数组
+func (e *redactEncoder) AddArray(key string, m zapcore.ArrayMarshaler) error {
+	if e.replace(key, func() (string, bool) { return "", false }) {
+		return nil
+	}
+	return e.ObjectEncoder.AddArray(key, &redactArray{m: m, r: e.r})
+}
+
+// redactArrayEncoder 脱敏数组中的对象
+type redactArrayEncoder struct {
+	zapcore.ArrayEncoder
+	r *redactor
+}
+
+// AppendObject 写入对象
+func (e *redactArrayEncoder) AppendObject(m zapcore.ObjectMarshaler) error {
+	return e.ArrayEncoder.AppendObject(&redactObject{m: m, r: e.r})
+}
+
+// AppendArray 写入数组
+func (e *redactArrayEncoder) AppendArray(m zapcore.ArrayMarshaler) error {
+	return e.ArrayEncoder.AppendArray(&redactArray{m: m, r: e.r})
+}
+
+// AppendReflected 写入任意值，结构体等按字段脱敏
+func (e *redactArrayEncoder) AppendReflected(value interface{}) error {
+	switch v := e.r.value(reflect.ValueOf(value)).(type) {
+	case zapcore.ObjectMarshaler:
+		return e.ArrayEncoder.AppendObject(v)
+	case zapcore.ArrayMarshaler:
+		return e.ArrayEncoder.AppendArray(v)
+	default:
+		return e.ArrayEncoder.AppendReflected(v)
+	}
+}
+
+// redactCore 在编码前脱敏日志字段
+type redactCore struct {
+	zapcore.Core
+	r *redactor
+}
+
+// With 添加字段
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{Core: c.Core.With(c.r.fields(fields)), r: c.r}
+}
+
+// Check 判断是否输出
+func (c *redactCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 写入日志
+func (c *redactCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, c.r.fields(fields))
+}
@@ -0,0 +1,130 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"simple/model"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type testProfile struct {
+	Mobile string `json:"mobile"`
+	Email  string `json:"email,omitempty"`
+}
+
+type testUser struct {
+	testBase
+	Username string            `json:"username"`
+	Secret   string            `json:"code" log:"redact"`
+	IDCard   *string           `json:"id_card" log:"mask"`
+	Profile  *testProfile      `json:"profile"`
+	Profiles []testProfile     `json:"profiles"`
+	Extra    map[string]string `json:"extra"`
+	Hidden   string            `json:"-"`
+}
+
+type testBase struct {
+	ID int64 `json:"id"`
+}
+
+type testObject struct{}
+
+func (testObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", "tom")
+	enc.AddString("AccessToken", "abc")
+	return enc.AddReflected("profile", testProfile{Mobile: "13812345678"})
+}
+
+// 创建输出 JSON 的测试日志
+func newRedactLogger(buf *bytes.Buffer) *zap.Logger {
+	r := newRedactor(&model.LogRedact{
+		Fields: []string{"password", "access_token"},
+		Mask:   []string{"mobile", "email"},
+	})
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	core := zapcore.NewCore(encoder, zapcore.AddSync(buf), zapcore.DebugLevel)
+	return zap.New(&redactCore{Core: core, r: r})
+}
+
+// 解析最后一行日志
+func lastEntry(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+	t.Helper()
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("unmarshal %q: %v", buf.String(), err)
+	}
+	buf.Reset()
+	return entry
+}
+
+// TestRedactStruct 测试按标签与字段名脱敏 zap.Any 的结构体
+func TestRedactStruct(t *testing.T) {
+	var buf bytes.Buffer
+	log := newRedactLogger(&buf)
+
+	idCard := "110101199001011234"
+	log.Info("user", zap.Any("user", &testUser{
+		testBase: testBase{ID: 1},
+		Username: "tom",
+		Secret:   "s3cret",
+		IDCard:   &idCard,
+		Profile:  &testProfile{Mobile: "13812345678", Email: "tom@example.com"},
+		Profiles: []testProfile{{Mobile: "13900001111"}},
+		Extra:    map[string]string{"Password": "p", "note": "n"},
+		Hidden:   "hidden",
+	}))
+
+	got, _ := json.Marshal(lastEntry(t, &buf)["user"])
+	want := `{"code":"******","extra":{"Password":"******","note":"n"},"id":1,"id_card":"110101******011234",` +
+		`"profile":{"email":"t*m@example.com","mobile":"138*****678"},"profiles":[{"mobile":"139*****111"}],"username":"tom"}`
+	if string(got) != want {
+		t.Errorf("user = %s\nwant %s", got, want)
+	}
+}
+
+// TestRedactFields 测试按键名脱敏普通字段、With 字段与 ObjectMarshaler
+func TestRedactFields(t *testing.T) {
+	var buf bytes.Buffer
+	log := newRedactLogger(&buf).With(zap.String("password", "p"))
+
+	log.Info("fields",
+		zap.String("mobile", "13812345678"),
+		zap.Object("obj", testObject{}),
+		zap.Any("ctx", context.Background()),
+		zap.Any("ids", []int64{1, 2}),
+	)
+
+	entry := lastEntry(t, &buf)
+	if entry["password"] != redacted || entry["mobile"] != "138*****678" {
+		t.Errorf("entry = %v", entry)
+	}
+	obj := entry["obj"].(map[string]interface{})
+	if obj["name"] != "tom" || obj["AccessToken"] != redacted ||
+		obj["profile"].(map[string]interface{})["mobile"] != "138*****678" {
+		t.Errorf("obj = %v", obj)
+	}
+	if ids, _ := json.Marshal(entry["ids"]); string(ids) != "[1,2]" {
+		t.Errorf("ids = %s", ids)
+	}
+}
+
+// TestMask 测试部分脱敏
+func TestMask(t *testing.T) {
+	cases := map[string]string{
+		"":                "",
+		"ab":              "**",
+		"abc":             "a*c",
+		"13812345678":     "138*****678",
+		"tom@example.com": "t*m@example.com",
+		"张三丰":             "张*丰",
+	}
+	for in, want := range cases {
+		if got := mask(in); got != want {
+			t.Errorf("mask(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package password
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"simple/model"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+/*
+   @NAME    : password
+   @author  : 清风
+   @desc    : 密码哈希，支持 argon2id 与 bcrypt，兼容旧的 MD5 加盐摘要
+   @time    : 2025/3/14 21:05
+*/
+
+// 哈希算法
+const (
+	AlgorithmArgon2id = "argon2id"
+	AlgorithmBcrypt   = "bcrypt"
+)
+
+// 默认参数
+const (
+	defaultBcryptCost        = bcrypt.DefaultCost
+	defaultArgon2Memory      = 64 * 1024 // 64MB
+	defaultArgon2Iterations  = 3
+	defaultArgon2Parallelism = 2
+	defaultArgon2SaltLength  = 16
+	defaultArgon2KeyLength   = 32
+)
+
+var (
+	ErrUnknownHash = errors.New("无法识别的密码哈希格式")
+
+	// 当前使用的配置
+	current = normalize(&model.PasswordConfig{})
+)
+
+// Setup 设置密码哈希配置
+func Setup(config *model.PasswordConfig) {
+	current = normalize(config)
+}
+
+// 补全默认值
+func normalize(config *model.PasswordConfig) model.PasswordConfig {
+	c := *config
+	if c.Algorithm != AlgorithmBcrypt {
+		c.Algorithm = AlgorithmArgon2id
+	}
+	if c.BcryptCost < bcrypt.MinCost || c.BcryptCost > bcrypt.MaxCost {
+		c.BcryptCost = defaultBcryptCost
+	}
+	if c.Argon2.Memory == 0 {
+		c.Argon2.Memory = defaultArgon2Memory
+	}
+	if c.Argon2.Iterations == 0 {
+		c.Argon2.Iterations = defaultArgon2Iterations
+	}
+	if c.Argon2.Parallelism == 0 {
+		c.Argon2.Parallelism = defaultArgon2Parallelism
+	}
+	if c.Argon2.SaltLength == 0 {
+		c.Argon2.SaltLength = defaultArgon2SaltLength
+	}
+	if c.Argon2.KeyLength == 0 {
+		c.Argon2.KeyLength = defaultArgon2KeyLength
+	}
+	return c
+}
+
+// Hash 使用配置的算法计算密码哈希，盐值已编码在结果中
+func Hash(plain string) (string, error) {
+	if current.Algorithm == AlgorithmBcrypt {
+		hash, err := bcrypt.GenerateFromPassword([]byte(plain), current.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("计算密码哈希失败: %w", err)
+		}
+		return string(hash), nil
+	}
+	return hashArgon2id(plain, current.Argon2)
+}
+
+// Verify 校验密码，salt 仅用于旧的 MD5 摘要。
+// needsRehash 为 true 表示哈希来自旧算法或旧参数，应在校验通过后重新计算并保存。
+func Verify(plain, hash, salt string) (ok bool, needsRehash bool, err error) {
+	switch {
+	case strings.HasPrefix(hash, "$argon2id$"):
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, false, err
+		}
+		other := argon2.IDKey([]byte(plain), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(key, other) != 1 {
+			return false, false, nil
+		}
+		return true, current.Algorithm != AlgorithmArgon2id || params != current.Argon2, nil
+
+	case strings.HasPrefix(hash, "$2a$"), strings.HasPrefix(hash, "$2b$"), strings.HasPrefix(hash, "$2y$"):
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(plain)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, false, nil
+			}
+			return false, false, err
+		}
+		cost, err := bcrypt.Cost([]byte(hash))
+		if err != nil {
+			return false, false, err
+		}
+		return true, current.Algorithm != AlgorithmBcrypt || cost != current.BcryptCost, nil
+
+	case isLegacy(hash):
+		sum := md5.Sum([]byte(plain + salt))
+		if subtle.ConstantTimeCompare([]byte(strings.ToLower(hash)), []byte(hex.EncodeToString(sum[:]))) != 1 {
+			return false, false, nil
+		}
+		return true, true, nil
+	}
+
+	return false, false, ErrUnknownHash
+}
+
+// 旧的 MD5(password + salt) 十六进制摘要
+func isLegacy(hash string) bool {
+	if len(hash) != md5.Size*2 {
+		return false
+	}
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
+
+// 计算 argon2id 哈希，输出 PHC 格式：$argon2id$v=19$m=65536,t=3,p=2$<salt>$<key>
+func hashArgon2id(plain string, params model.Argon2Config) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("生成盐值失败: %w", err)
+	}
+	key := argon2.IDKey([]byte(plain), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// 解析 PHC 格式的 argon2id 哈希
+func decodeArgon2id(hash string) (model.Argon2Config, []byte, []byte, error) {
+	var params model.Argon2Config
+
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+	return params, salt, key, nil
+}
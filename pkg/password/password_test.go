@@ -0,0 +1,97 @@
+package password
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"simple/model"
+	"testing"
+)
+
+// 测试用的低成本参数
+func testConfig(algorithm string) *model.PasswordConfig {
+	return &model.PasswordConfig{
+		Algorithm:  algorithm,
+		BcryptCost: 4,
+		Argon2: model.Argon2Config{
+			Memory:      1024,
+			Iterations:  1,
+			Parallelism: 1,
+			SaltLength:  16,
+			KeyLength:   32,
+		},
+	}
+}
+
+// TestHashAndVerify 测试哈希与校验
+func TestHashAndVerify(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmArgon2id, AlgorithmBcrypt} {
+		t.Run(algorithm, func(t *testing.T) {
+			Setup(testConfig(algorithm))
+
+			hash, err := Hash("123456")
+			if err != nil {
+				t.Fatalf("Hash failed: %v", err)
+			}
+
+			ok, rehash, err := Verify("123456", hash, "")
+			if err != nil || !ok || rehash {
+				t.Errorf("Verify(correct) = %v, %v, %v", ok, rehash, err)
+			}
+
+			ok, _, err = Verify("654321", hash, "")
+			if err != nil || ok {
+				t.Errorf("Verify(wrong) = %v, %v", ok, err)
+			}
+		})
+	}
+}
+
+// TestNeedsRehash 测试旧哈希与参数变化时需要重新哈希
+func TestNeedsRehash(t *testing.T) {
+	t.Run("Legacy MD5", func(t *testing.T) {
+		Setup(testConfig(AlgorithmArgon2id))
+
+		sum := md5.Sum([]byte("123456" + "abcdefghij"))
+		legacy := hex.EncodeToString(sum[:])
+
+		ok, rehash, err := Verify("123456", legacy, "abcdefghij")
+		if err != nil || !ok || !rehash {
+			t.Errorf("Verify(legacy) = %v, %v, %v", ok, rehash, err)
+		}
+
+		ok, _, _ = Verify("123456", legacy, "wrong-salt")
+		if ok {
+			t.Error("expected legacy hash with wrong salt to fail")
+		}
+	})
+
+	t.Run("Algorithm Changed", func(t *testing.T) {
+		Setup(testConfig(AlgorithmBcrypt))
+		hash, _ := Hash("123456")
+
+		Setup(testConfig(AlgorithmArgon2id))
+		ok, rehash, err := Verify("123456", hash, "")
+		if err != nil || !ok || !rehash {
+			t.Errorf("Verify(bcrypt under argon2id) = %v, %v, %v", ok, rehash, err)
+		}
+	})
+
+	t.Run("Params Changed", func(t *testing.T) {
+		Setup(testConfig(AlgorithmArgon2id))
+		hash, _ := Hash("123456")
+
+		config := testConfig(AlgorithmArgon2id)
+		config.Argon2.Iterations = 2
+		Setup(config)
+		ok, rehash, err := Verify("123456", hash, "")
+		if err != nil || !ok || !rehash {
+			t.Errorf("Verify(old params) = %v, %v, %v", ok, rehash, err)
+		}
+	})
+
+	t.Run("Unknown Format", func(t *testing.T) {
+		if _, _, err := Verify("123456", "plain-text", ""); err != ErrUnknownHash {
+			t.Errorf("expected ErrUnknownHash, got %v", err)
+		}
+	})
+}
@@ -15,6 +15,9 @@ import (
    @time    : 2025/3/6 23:20
 */
 
+// TIDKey 链路追踪ID在 gin.Context 中的键，由追踪中间件写入
+const TIDKey = "tid"
+
 type Response struct {
 	Code int    `json:"code"`
 	Data any    `json:"data"`
@@ -42,16 +45,23 @@ func NewPageResp(list any, total int64) *PageResp {
 	}
 }
 
+// 创建响应并带上当前请求的链路追踪ID
+func newResponse(ctx *gin.Context, code int, data any, msg string) *Response {
+	r := NewResponse(code, data, msg)
+	r.TID = ctx.GetString(TIDKey)
+	return r
+}
+
 func ok(ctx *gin.Context, data any) {
-	ctx.JSON(http.StatusOK, NewResponse(200, data, "ok"))
+	ctx.JSON(http.StatusOK, newResponse(ctx, 200, data, "ok"))
 }
 
 func okNil(ctx *gin.Context) {
-	ctx.JSON(http.StatusOK, NewResponse(200, nil, "ok"))
+	ctx.JSON(http.StatusOK, newResponse(ctx, 200, nil, "ok"))
 }
 
 func fail(ctx *gin.Context, err error) {
-	ctx.JSON(http.StatusOK, NewResponse(consts.GC(err), nil, err.Error()))
+	ctx.JSON(http.StatusOK, newResponse(ctx, consts.GC(err), nil, err.Error()))
 }
 
 // Res 通用响应
@@ -64,20 +74,39 @@ func Res(ctx *gin.Context, err error, data ...any) {
 		okNil(ctx)
 		return
 	}
+	if len(data) == 1 {
+		ok(ctx, data[0])
+		return
+	}
 
 	ok(ctx, data)
 }
 
-// RefreshToken 刷新token
-func RefreshToken(ctx *gin.Context, token, refresh string, expire int64) {
+// SetToken 在响应头中写入新的令牌，不影响本次响应
+func SetToken(ctx *gin.Context, token, refresh string, expire int64) {
 	ctx.Header("u", token)
 	ctx.Header("refresh", refresh)
 	ctx.Header("expire", fmt.Sprintf("%d", expire))
+}
+
+// RefreshToken 刷新token
+func RefreshToken(ctx *gin.Context, token, refresh string, expire int64) {
+	SetToken(ctx, token, refresh, expire)
+
+	ctx.JSON(http.StatusUnauthorized, newResponse(ctx, consts.GC(consts.ErrUnauthorized), nil, consts.ErrUnauthorized.Error()))
+}
+
+// Unauthorized 认证失败，返回401并终止后续处理
+func Unauthorized(ctx *gin.Context, err error) {
+	ctx.AbortWithStatusJSON(http.StatusUnauthorized, newResponse(ctx, consts.GC(err), nil, err.Error()))
+}
 
-	ctx.JSON(http.StatusUnauthorized, NewResponse(consts.GC(consts.ErrUnauthorized), nil, consts.ErrUnauthorized.Error()))
+// Forbidden 权限不足，返回403并终止后续处理
+func Forbidden(ctx *gin.Context, err error) {
+	ctx.AbortWithStatusJSON(http.StatusForbidden, newResponse(ctx, consts.GC(err), nil, err.Error()))
 }
 
 // 用于处理404错误
 func NotFound(ctx *gin.Context) {
-	ctx.JSON(http.StatusOK, NewResponse(consts.GC(consts.ErrNotFound), nil, consts.ErrNotFound.Error()))
+	ctx.JSON(http.StatusOK, newResponse(ctx, consts.GC(consts.ErrNotFound), nil, consts.ErrNotFound.Error()))
 }
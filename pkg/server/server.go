@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"simple/model"
+	"simple/pkg/logger"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+/*
+   @NAME    : server
+   @author  : 清风
+   @desc    : HTTP 服务启动与优雅关闭
+   @time    : 2025/3/10 21:15
+*/
+
+// 默认的优雅关闭等待时间
+const defaultShutdownTimeout = 10 * time.Second
+
+// Server HTTP服务
+type Server struct {
+	config *model.ServerConfig
+	engine *gin.Engine
+	srv    *http.Server
+}
+
+// NewEngine 根据配置创建 Gin 引擎。
+// 只信任配置的代理转发的 X-Forwarded-For，未配置时客户端IP取连接的地址。
+func NewEngine(config *model.ServerConfig) (*gin.Engine, error) {
+	switch config.Mode {
+	case gin.DebugMode, gin.ReleaseMode, gin.TestMode:
+		gin.SetMode(config.Mode)
+	default:
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	engine := gin.New()
+	if err := engine.SetTrustedProxies(config.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("可信代理配置错误: %w", err)
+	}
+	if gin.Mode() == gin.DebugMode {
+		engine.Use(gin.Logger())
+	}
+	engine.Use(gin.Recovery())
+
+	// 静态资源目录存在时才挂载
+	if config.Static != "" {
+		if info, err := os.Stat(config.Static); err == nil && info.IsDir() {
+			engine.Static("/static", config.Static)
+		}
+	}
+
+	return engine, nil
+}
+
+// New 创建HTTP服务
+func New(config *model.ServerConfig, engine *gin.Engine) *Server {
+	return &Server{
+		config: config,
+		engine: engine,
+		srv: &http.Server{
+			Addr:         fmt.Sprintf(":%d", config.Port),
+			Handler:      engine,
+			ReadTimeout:  config.ReadTimeout,
+			WriteTimeout: config.WriteTimeout,
+		},
+	}
+}
+
+// Engine 获取 Gin 引擎
+func (s *Server) Engine() *gin.Engine {
+	return s.engine
+}
+
+// Run 启动服务并阻塞，直到收到 SIGINT/SIGTERM 后优雅关闭
+func (s *Server) Run() error {
+	errCh := make(chan error, 1)
+	go func() {
+		logger.Info("HTTP 服务启动", zap.String("addr", s.srv.Addr), zap.String("mode", gin.Mode()))
+		if err := s.srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errCh <- err
+		}
+		close(errCh)
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	select {
+	case err, ok := <-errCh:
+		if ok {
+			return fmt.Errorf("HTTP 服务启动失败: %w", err)
+		}
+		return nil
+	case sig := <-quit:
+		logger.Info("收到退出信号，开始关闭 HTTP 服务", zap.String("signal", sig.String()))
+	}
+
+	return s.Shutdown()
+}
+
+// Shutdown 优雅关闭服务，等待处理中的请求完成
+func (s *Server) Shutdown() error {
+	timeout := s.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := s.srv.Shutdown(ctx); err != nil {
+		return fmt.Errorf("HTTP 服务关闭失败: %w", err)
+	}
+	logger.Info("HTTP 服务关闭成功")
+	return nil
+}
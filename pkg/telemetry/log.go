@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"simple/model"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+/*
+   @NAME    : log
+   @author  : 清风
+   @desc    : 日志 LoggerProvider，由 logger 包桥接 zap 日志
+   @time    : 2025/3/28 20:15
+*/
+
+// 安装全局 LoggerProvider
+func setupLogs(config *model.TelemetryConfig, res *resource.Resource) error {
+	exporter, err := newLogExporter(&config.OTLP)
+	if err != nil {
+		return fmt.Errorf("创建日志导出器失败: %w", err)
+	}
+
+	var opts []sdklog.BatchProcessorOption
+	if config.OTLP.Timeout > 0 {
+		opts = append(opts, sdklog.WithExportTimeout(config.OTLP.Timeout))
+	}
+
+	provider := sdklog.NewLoggerProvider(
+		sdklog.WithResource(res),
+		sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, opts...)),
+	)
+	global.SetLoggerProvider(provider)
+	onShutdown(provider.Shutdown)
+	return nil
+}
+
+// 创建 OTLP 日志导出器
+func newLogExporter(otlp *model.OTLPConfig) (sdklog.Exporter, error) {
+	return newOTLPExporter(otlp,
+		otlpClient[sdklog.Exporter, otlploghttp.Option]{
+			new: func(ctx context.Context, opts ...otlploghttp.Option) (sdklog.Exporter, error) {
+				return otlploghttp.New(ctx, opts...)
+			},
+			endpoint:    otlploghttp.WithEndpoint,
+			endpointURL: otlploghttp.WithEndpointURL,
+			insecure:    otlploghttp.WithInsecure,
+			timeout:     otlploghttp.WithTimeout,
+		},
+		otlpClient[sdklog.Exporter, otlploggrpc.Option]{
+			new: func(ctx context.Context, opts ...otlploggrpc.Option) (sdklog.Exporter, error) {
+				return otlploggrpc.New(ctx, opts...)
+			},
+			endpoint:    otlploggrpc.WithEndpoint,
+			endpointURL: otlploggrpc.WithEndpointURL,
+			insecure:    otlploggrpc.WithInsecure,
+			timeout:     otlploggrpc.WithTimeout,
+		},
+	)
+}
@@ -0,0 +1,117 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"simple/model"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+/*
+   @NAME    : metric
+   @author  : 清风
+   @desc    : 指标 MeterProvider
+   @time    : 2025/3/27 20:30
+*/
+
+// ExporterPrometheus 指标由 Prometheus 拉取
+const ExporterPrometheus = "prometheus"
+
+// 默认的 Prometheus 拉取地址
+const defaultMetricsPath = "/metrics"
+
+// prometheus 导出时的拉取接口
+var metricsHandler http.Handler
+
+// MetricsHandler 获取 Prometheus 拉取接口，未使用 prometheus 导出时返回nil
+func MetricsHandler() http.Handler {
+	return metricsHandler
+}
+
+// MetricsPath 获取 Prometheus 拉取地址
+func MetricsPath(config *model.MetricsConfig) string {
+	if config.Path == "" {
+		return defaultMetricsPath
+	}
+	return config.Path
+}
+
+// 安装全局 MeterProvider
+func setupMetrics(config *model.TelemetryConfig, res *resource.Resource) error {
+	reader, err := newMetricReader(config)
+	if err != nil {
+		return fmt.Errorf("创建指标导出器失败: %w", err)
+	}
+
+	provider := sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(reader),
+	)
+	otel.SetMeterProvider(provider)
+	onShutdown(provider.Shutdown)
+	return nil
+}
+
+// 根据配置创建读取器，otlp 按上报间隔定时采集推送，prometheus 在拉取时采集
+func newMetricReader(config *model.TelemetryConfig) (sdkmetric.Reader, error) {
+	switch config.Metrics.Exporter {
+	case ExporterPrometheus:
+		registry := prometheus.NewRegistry()
+		exporter, err := otelprom.New(otelprom.WithRegisterer(registry))
+		if err != nil {
+			return nil, err
+		}
+		metricsHandler = promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+		return exporter, nil
+	case "", ExporterOTLP:
+	default:
+		return nil, fmt.Errorf("不支持的导出方式: %s", config.Metrics.Exporter)
+	}
+
+	exporter, err := newMetricExporter(&config.OTLP)
+	if err != nil {
+		return nil, err
+	}
+
+	var opts []sdkmetric.PeriodicReaderOption
+	if config.Metrics.Interval > 0 {
+		opts = append(opts, sdkmetric.WithInterval(config.Metrics.Interval))
+	}
+	if config.OTLP.Timeout > 0 {
+		opts = append(opts, sdkmetric.WithTimeout(config.OTLP.Timeout))
+	}
+	return sdkmetric.NewPeriodicReader(exporter, opts...), nil
+}
+
+// 创建 OTLP 指标导出器
+func newMetricExporter(otlp *model.OTLPConfig) (sdkmetric.Exporter, error) {
+	return newOTLPExporter(otlp,
+		otlpClient[sdkmetric.Exporter, otlpmetrichttp.Option]{
+			new: func(ctx context.Context, opts ...otlpmetrichttp.Option) (sdkmetric.Exporter, error) {
+				return otlpmetrichttp.New(ctx, opts...)
+			},
+			endpoint:    otlpmetrichttp.WithEndpoint,
+			endpointURL: otlpmetrichttp.WithEndpointURL,
+			insecure:    otlpmetrichttp.WithInsecure,
+			timeout:     otlpmetrichttp.WithTimeout,
+		},
+		otlpClient[sdkmetric.Exporter, otlpmetricgrpc.Option]{
+			new: func(ctx context.Context, opts ...otlpmetricgrpc.Option) (sdkmetric.Exporter, error) {
+				return otlpmetricgrpc.New(ctx, opts...)
+			},
+			endpoint:    otlpmetricgrpc.WithEndpoint,
+			endpointURL: otlpmetricgrpc.WithEndpointURL,
+			insecure:    otlpmetricgrpc.WithInsecure,
+			timeout:     otlpmetricgrpc.WithTimeout,
+		},
+	)
+}
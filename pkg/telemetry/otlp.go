@@ -0,0 +1,53 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"simple/model"
+	"strings"
+	"time"
+)
+
+/*
+   @NAME    : otlp
+   @author  : 清风
+   @desc    : OTLP 导出器公共配置，链路追踪、指标与日志共用
+   @time    : 2025/3/30 21:40
+*/
+
+// otlpClient 某一协议的 OTLP 导出器构造函数与选项
+type otlpClient[E, O any] struct {
+	new         func(context.Context, ...O) (E, error)
+	endpoint    func(string) O
+	endpointURL func(string) O
+	insecure    func() O
+	timeout     func(time.Duration) O
+}
+
+// 根据配置生成选项，端点支持 host:port 与完整 URL 两种写法
+func (c otlpClient[E, O]) options(otlp *model.OTLPConfig) []O {
+	opts := []O{c.endpoint(otlp.Endpoint)}
+	if strings.Contains(otlp.Endpoint, "://") {
+		opts = []O{c.endpointURL(otlp.Endpoint)}
+	}
+	if otlp.Insecure {
+		opts = append(opts, c.insecure())
+	}
+	if otlp.Timeout > 0 {
+		opts = append(opts, c.timeout(otlp.Timeout))
+	}
+	return opts
+}
+
+// 按配置的协议创建 OTLP 导出器，默认使用 gRPC
+func newOTLPExporter[E, H, G any](otlp *model.OTLPConfig, http otlpClient[E, H], grpc otlpClient[E, G]) (E, error) {
+	switch otlp.Protocol {
+	case ProtocolHTTP:
+		return http.new(context.Background(), http.options(otlp)...)
+	case "", ProtocolGRPC:
+		return grpc.new(context.Background(), grpc.options(otlp)...)
+	default:
+		var zero E
+		return zero, fmt.Errorf("不支持的 OTLP 协议: %s", otlp.Protocol)
+	}
+}
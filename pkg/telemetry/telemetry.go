@@ -0,0 +1,119 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"simple/model"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+/*
+   @NAME    : telemetry
+   @author  : 清风
+   @desc    : OpenTelemetry 初始化，根据配置安装全局 Provider
+   @time    : 2025/3/25 20:40
+*/
+
+// OTLP 协议
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+)
+
+// 导出方式
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterMemory = "memory"
+)
+
+var (
+	mu        sync.Mutex
+	shutdowns []func(context.Context) error
+)
+
+// Setup 根据配置初始化链路追踪、指标与日志，未启用的部分保持 OpenTelemetry 默认的空实现
+func Setup(config *model.TelemetryConfig) error {
+	res, err := newResource(config)
+	if err != nil {
+		return fmt.Errorf("创建遥测资源失败: %w", err)
+	}
+
+	// W3C traceparent 与 baggage 传播
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if config.Trace.Enabled {
+		if err = setupTrace(config, res); err != nil {
+			return err
+		}
+	}
+	if config.Metrics.Enabled {
+		if err = setupMetrics(config, res); err != nil {
+			return err
+		}
+	}
+	if config.Logs.Enabled {
+		if err = setupLogs(config, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Shutdown 刷新并关闭已安装的 Provider，按初始化的逆序执行
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	fns := shutdowns
+	shutdowns = nil
+	mu.Unlock()
+
+	var errs []error
+	for i := len(fns) - 1; i >= 0; i-- {
+		errs = append(errs, fns[i](ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// 登记关闭函数
+func onShutdown(fn func(context.Context) error) {
+	mu.Lock()
+	defer mu.Unlock()
+	shutdowns = append(shutdowns, fn)
+}
+
+// 服务资源，包含服务名称与自定义属性
+func newResource(config *model.TelemetryConfig) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{semconv.ServiceName(config.ServiceName)}
+	for key, value := range config.Trace.Attributes {
+		attrs = append(attrs, toAttribute(key, value))
+	}
+	return resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, attrs...))
+}
+
+// 配置中的属性值转换为 attribute
+func toAttribute(key string, value interface{}) attribute.KeyValue {
+	switch v := value.(type) {
+	case string:
+		return attribute.String(key, v)
+	case bool:
+		return attribute.Bool(key, v)
+	case int:
+		return attribute.Int(key, v)
+	case int64:
+		return attribute.Int64(key, v)
+	case float64:
+		return attribute.Float64(key, v)
+	default:
+		return attribute.String(key, fmt.Sprint(v))
+	}
+}
@@ -0,0 +1,104 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"simple/model"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// TestSetupMemory 测试内存导出器记录 span 与资源属性
+func TestSetupMemory(t *testing.T) {
+	err := Setup(&model.TelemetryConfig{
+		ServiceName:  "simple-test",
+		SamplingRate: 1,
+		Trace: model.TraceConfig{
+			Enabled:    true,
+			Exporter:   ExporterMemory,
+			Attributes: map[string]interface{}{"environment": "test", "shard": 3},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	defer Shutdown(context.Background())
+
+	_, span := otel.Tracer("test").Start(context.Background(), "op")
+	span.End()
+
+	spans := MemoryExporter().GetSpans()
+	if len(spans) != 1 || spans[0].Name != "op" {
+		t.Fatalf("spans = %v", spans)
+	}
+
+	want := map[attribute.Key]attribute.Value{
+		"service.name": attribute.StringValue("simple-test"),
+		"environment":  attribute.StringValue("test"),
+		"shard":        attribute.IntValue(3),
+	}
+	for key, value := range want {
+		got, ok := spans[0].Resource.Set().Value(key)
+		if !ok || got != value {
+			t.Errorf("resource %s = %v, want %v", key, got.Emit(), value.Emit())
+		}
+	}
+}
+
+// TestSamplingRate 测试采样率为0时不记录 span
+func TestSamplingRate(t *testing.T) {
+	err := Setup(&model.TelemetryConfig{
+		ServiceName:  "simple-test",
+		SamplingRate: 0,
+		Trace:        model.TraceConfig{Enabled: true, Exporter: ExporterMemory},
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	defer Shutdown(context.Background())
+
+	_, span := otel.Tracer("test").Start(context.Background(), "op")
+	span.End()
+
+	if spans := MemoryExporter().GetSpans(); len(spans) != 0 {
+		t.Errorf("spans = %d, want 0", len(spans))
+	}
+}
+
+// TestUnknownExporter 测试不支持的导出方式
+func TestUnknownExporter(t *testing.T) {
+	err := Setup(&model.TelemetryConfig{
+		Trace: model.TraceConfig{Enabled: true, Exporter: "zipkin"},
+	})
+	if err == nil {
+		t.Error("Setup(zipkin) should fail")
+	}
+}
+
+// TestPrometheusHandler 测试 prometheus 拉取接口输出指标
+func TestPrometheusHandler(t *testing.T) {
+	err := Setup(&model.TelemetryConfig{
+		ServiceName: "simple-test",
+		Metrics:     model.MetricsConfig{Enabled: true, Exporter: ExporterPrometheus},
+	})
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	defer Shutdown(context.Background())
+
+	counter, err := otel.Meter("test").Int64Counter("test.requests")
+	if err != nil {
+		t.Fatalf("Int64Counter failed: %v", err)
+	}
+	counter.Add(context.Background(), 3)
+
+	w := httptest.NewRecorder()
+	MetricsHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, defaultMetricsPath, nil))
+	if body := w.Body.String(); !strings.Contains(body, "test_requests_total") {
+		t.Errorf("metrics output missing counter:\n%s", body)
+	}
+}
@@ -0,0 +1,90 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"simple/model"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+/*
+   @NAME    : trace
+   @author  : 清风
+   @desc    : 链路追踪 TracerProvider
+   @time    : 2025/3/25 20:55
+*/
+
+// 内存导出器，exporter 为 memory 时使用
+var memoryExporter = tracetest.NewInMemoryExporter()
+
+// MemoryExporter 获取内存导出器，用于测试中读取已结束的 span
+func MemoryExporter() *tracetest.InMemoryExporter {
+	return memoryExporter
+}
+
+// 安装全局 TracerProvider
+func setupTrace(config *model.TelemetryConfig, res *resource.Resource) error {
+	exporter, err := newTraceExporter(config)
+	if err != nil {
+		return fmt.Errorf("创建链路追踪导出器失败: %w", err)
+	}
+
+	// 内存导出器同步导出，便于测试立即读取
+	var processor sdktrace.SpanProcessor
+	if config.Trace.Exporter == ExporterMemory {
+		processor = sdktrace.NewSimpleSpanProcessor(exporter)
+	} else {
+		processor = sdktrace.NewBatchSpanProcessor(exporter)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SamplingRate))),
+		sdktrace.WithSpanProcessor(processor),
+	)
+	otel.SetTracerProvider(provider)
+	onShutdown(provider.Shutdown)
+	return nil
+}
+
+// 根据配置创建导出器
+func newTraceExporter(config *model.TelemetryConfig) (sdktrace.SpanExporter, error) {
+	switch config.Trace.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterMemory:
+		memoryExporter.Reset()
+		return memoryExporter, nil
+	case "", ExporterOTLP:
+	default:
+		return nil, fmt.Errorf("不支持的导出方式: %s", config.Trace.Exporter)
+	}
+
+	return newOTLPExporter(&config.OTLP,
+		otlpClient[sdktrace.SpanExporter, otlptracehttp.Option]{
+			new: func(ctx context.Context, opts ...otlptracehttp.Option) (sdktrace.SpanExporter, error) {
+				return otlptracehttp.New(ctx, opts...)
+			},
+			endpoint:    otlptracehttp.WithEndpoint,
+			endpointURL: otlptracehttp.WithEndpointURL,
+			insecure:    otlptracehttp.WithInsecure,
+			timeout:     otlptracehttp.WithTimeout,
+		},
+		otlpClient[sdktrace.SpanExporter, otlptracegrpc.Option]{
+			new: func(ctx context.Context, opts ...otlptracegrpc.Option) (sdktrace.SpanExporter, error) {
+				return otlptracegrpc.New(ctx, opts...)
+			},
+			endpoint:    otlptracegrpc.WithEndpoint,
+			endpointURL: otlptracegrpc.WithEndpointURL,
+			insecure:    otlptracegrpc.WithInsecure,
+			timeout:     otlptracegrpc.WithTimeout,
+		},
+	)
+}
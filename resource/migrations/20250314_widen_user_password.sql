@@ -0,0 +1,7 @@
+-- ----------------------------
+-- 扩大 sys_user.password 长度以保存 argon2id/bcrypt 哈希
+-- 旧的 MD5 密码仍保留在原字段中，用户下次登录成功后自动重新哈希
+-- ----------------------------
+ALTER TABLE `sys_user`
+  MODIFY COLUMN `password` varchar(255) COLLATE utf8mb4_general_ci NOT NULL COMMENT '密码哈希|Password hash',
+  MODIFY COLUMN `salt` varchar(10) COLLATE utf8mb4_general_ci NOT NULL COMMENT '旧MD5密码的盐值|Legacy MD5 salt';
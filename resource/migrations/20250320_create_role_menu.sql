@@ -0,0 +1,15 @@
+-- ----------------------------
+-- 新增角色与菜单的授权关系表
+-- ----------------------------
+CREATE TABLE `sys_role_menu` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID|Primary key',
+  `role_id` bigint unsigned NOT NULL COMMENT '角色ID|Role ID',
+  `menu_id` bigint unsigned NOT NULL COMMENT '菜单ID|Menu ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间|Created Time',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间|Updated Time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_role_menu` (`role_id`,`menu_id`),
+  KEY `idx_menu_id` (`menu_id`),
+  CONSTRAINT `fk_role_menus_menu` FOREIGN KEY (`menu_id`) REFERENCES `sys_menu` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_role_menus_role` FOREIGN KEY (`role_id`) REFERENCES `sys_role` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='角色-菜单关系表';
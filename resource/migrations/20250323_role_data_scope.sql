@@ -0,0 +1,18 @@
+-- ----------------------------
+-- 角色增加数据范围，自定义数据范围的部门保存在 sys_role_department
+-- ----------------------------
+ALTER TABLE `sys_role`
+  ADD COLUMN `data_scope` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '数据范围 1:全部 2:本部门 3:本部门及以下 4:仅本人 5:自定义部门|Data scope 1:All 2:Department 3:Department and below 4:Self 5:Custom' AFTER `sort`;
+
+CREATE TABLE `sys_role_department` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID|Primary key',
+  `role_id` bigint unsigned NOT NULL COMMENT '角色ID|Role ID',
+  `department_id` bigint unsigned NOT NULL COMMENT '部门ID|Department ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间|Created Time',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间|Updated Time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_role_department` (`role_id`,`department_id`),
+  KEY `idx_department_id` (`department_id`),
+  CONSTRAINT `fk_role_departments_department` FOREIGN KEY (`department_id`) REFERENCES `sys_department` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_role_departments_role` FOREIGN KEY (`role_id`) REFERENCES `sys_role` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='角色-数据权限部门关系表';
@@ -0,0 +1,8 @@
+-- ----------------------------
+-- sys_user.home_path 允许为空，未设置时使用角色的默认路由
+-- 原有的默认值 /dashboard 视为未设置
+-- ----------------------------
+ALTER TABLE `sys_user`
+  MODIFY COLUMN `home_path` varchar(128) COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '首页路径，为空时使用角色的默认路由|Home Path';
+
+UPDATE `sys_user` SET `home_path` = NULL WHERE `home_path` = '/dashboard';
@@ -115,6 +115,7 @@ CREATE TABLE `sys_role` (
   `status` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '状态 1:启用 2:禁用|Status 1:Enable 2:Disable',
   `remark` varchar(255) COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '备注|Remark',
   `sort` int unsigned NOT NULL DEFAULT '0' COMMENT '排序|Sort',
+  `data_scope` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '数据范围 1:全部 2:本部门 3:本部门及以下 4:仅本人 5:自定义部门|Data scope 1:All 2:Department 3:Department and below 4:Self 5:Custom',
   `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间|Created Time',
   `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间|Updated Time',
   `deleted_at` datetime DEFAULT NULL COMMENT '删除时间|Deleted Time',
@@ -125,6 +126,40 @@ CREATE TABLE `sys_role` (
   KEY `idx_deleted_at` (`deleted_at`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='系统角色表';
 
+-- ----------------------------
+-- Table structure for sys_role_department
+-- ----------------------------
+DROP TABLE IF EXISTS `sys_role_department`;
+CREATE TABLE `sys_role_department` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID|Primary key',
+  `role_id` bigint unsigned NOT NULL COMMENT '角色ID|Role ID',
+  `department_id` bigint unsigned NOT NULL COMMENT '部门ID|Department ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间|Created Time',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间|Updated Time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_role_department` (`role_id`,`department_id`),
+  KEY `idx_department_id` (`department_id`),
+  CONSTRAINT `fk_role_departments_department` FOREIGN KEY (`department_id`) REFERENCES `sys_department` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_role_departments_role` FOREIGN KEY (`role_id`) REFERENCES `sys_role` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='角色-数据权限部门关系表';
+
+-- ----------------------------
+-- Table structure for sys_role_menu
+-- ----------------------------
+DROP TABLE IF EXISTS `sys_role_menu`;
+CREATE TABLE `sys_role_menu` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID|Primary key',
+  `role_id` bigint unsigned NOT NULL COMMENT '角色ID|Role ID',
+  `menu_id` bigint unsigned NOT NULL COMMENT '菜单ID|Menu ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '创建时间|Created Time',
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT '更新时间|Updated Time',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `idx_role_menu` (`role_id`,`menu_id`),
+  KEY `idx_menu_id` (`menu_id`),
+  CONSTRAINT `fk_role_menus_menu` FOREIGN KEY (`menu_id`) REFERENCES `sys_menu` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `fk_role_menus_role` FOREIGN KEY (`role_id`) REFERENCES `sys_role` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci COMMENT='角色-菜单关系表';
+
 -- ----------------------------
 -- Table structure for sys_user
 -- ----------------------------
@@ -133,8 +168,8 @@ CREATE TABLE `sys_user` (
   `id` bigint unsigned NOT NULL AUTO_INCREMENT COMMENT '主键ID|Primary key',
   `uuid` char(36) COLLATE utf8mb4_general_ci NOT NULL COMMENT '唯一标识符|UUID',
   `username` varchar(32) COLLATE utf8mb4_general_ci NOT NULL COMMENT '用户名|Username',
-  `password` varchar(32) COLLATE utf8mb4_general_ci NOT NULL COMMENT '密码|Password',
-  `salt` varchar(10) COLLATE utf8mb4_general_ci NOT NULL COMMENT '盐值|Salt',
+  `password` varchar(255) COLLATE utf8mb4_general_ci NOT NULL COMMENT '密码哈希|Password hash',
+  `salt` varchar(10) COLLATE utf8mb4_general_ci NOT NULL COMMENT '旧MD5密码的盐值|Legacy MD5 salt',
   `name` varchar(32) COLLATE utf8mb4_general_ci NOT NULL COMMENT '姓名|Name',
   `nickname` varchar(64) COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '昵称|Nickname',
   `email` varchar(64) COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '邮箱|Email',
@@ -142,7 +177,7 @@ CREATE TABLE `sys_user` (
   `avatar` varchar(255) COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '头像|Avatar',
   `status` tinyint unsigned NOT NULL DEFAULT '1' COMMENT '状态 1:启用 2:禁用|Status 1:Enable 2:Disable',
   `remark` varchar(255) COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '备注|Remark',
-  `home_path` varchar(128) COLLATE utf8mb4_general_ci NOT NULL DEFAULT '/dashboard' COMMENT '首页路径|Home Path',
+  `home_path` varchar(128) COLLATE utf8mb4_general_ci DEFAULT NULL COMMENT '首页路径，为空时使用角色的默认路由|Home Path',
   `department_id` bigint unsigned DEFAULT NULL COMMENT '部门ID|Department ID',
   `position_id` bigint unsigned DEFAULT NULL COMMENT '岗位ID|Position ID',
   `last_login_at` datetime DEFAULT NULL COMMENT '最后登录时间|Last Login Time',